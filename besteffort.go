@@ -0,0 +1,285 @@
+package pgtree
+
+import (
+	"strconv"
+	"strings"
+
+	nodes "github.com/pganalyze/pg_query_go/v6"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxFallbackEnds bounds the candidate ends tried for the source text of a node.
+const maxFallbackEnds = 32
+
+// Passthrough is a span of the source that the best effort printer copied verbatim.
+type Passthrough struct {
+	Statement  int   // Index of the statement, -1 when printing a single node.
+	Start, End int   // Byte offsets of the copied text in the source.
+	Err        error // Why the node or statement could not be printed.
+}
+
+// FormatBestEffort formats like Format, except that the nodes which cannot be printed are copied
+// verbatim from the source.  The source text of a node is found from its location, the statement is
+// copied whole when the node has none.  The copied spans are returned, an error is only returned when
+// the SQL does not parse.
+func FormatBestEffort(sql string, opts FormatOptions) (string, []Passthrough, error) {
+	return format(sql, opts, true)
+}
+
+// PrintBestEffort renders the Node like PrintWithOptions, except that the nodes which cannot be
+// printed are copied verbatim from sql, the source the Node was parsed from.  The copied spans are
+// returned, an error is returned when the source text of a node cannot be found.
+func PrintBestEffort(root *nodes.Node, sql string, opts FormatOptions) (string, []Passthrough, error) {
+	p := printer{FormatOptions: opts, statement: -1, source: sql, bestEffort: true}
+
+	result, err := p.print(root)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return result, p.passthrough, nil
+}
+
+// PrintParseResultBestEffort renders the statements of the parse result like PrintParseResult, except
+// that the nodes which cannot be printed are copied verbatim from sql, the source of the parse result.
+// The statements holding a node whose source text cannot be found are copied whole.
+func PrintParseResultBestEffort(parseResult *nodes.ParseResult, sql string, opts FormatOptions) (string, []Passthrough, error) {
+	printed, passthrough, err := printStatements(parseResult, sql, scanTokens(sql), opts, true, nil, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return strings.Join(printed, ""), passthrough, nil
+}
+
+// fallback is a node printed as its source text.
+type fallback struct {
+	node  *nodes.Node
+	held  int     // index of the held text replacing the node
+	start int     // offset of the text in the source
+	delta int     // offset of the location of the node in the text
+	ends  []int   // candidate ends of the text
+	end   int     // index of the current end in ends
+	errs  []error // errors printing the node
+}
+
+// passThrough replaces the printed node, whose printing added the errors after errs, by its source
+// text.  The result is left unchanged, with the errors, when the node cannot be found in the source.
+func (p *printer) passThrough(node *nodes.Node, errs int, result string) string {
+	loc := int(nodeLocation(node))
+	if p.source == "" || loc < 0 || loc >= len(p.source) {
+		return result
+	}
+
+	start, last := loc, loc
+
+	walkLocations(node.ProtoReflect(), func(l int) {
+		start, last = min(start, l), max(last, l)
+	})
+
+	ends := p.fallbackEnds(start, last)
+	if len(ends) == 0 {
+		return result
+	}
+
+	p.held = append(p.held, p.source[start:ends[0]])
+	p.fallbacks = append(p.fallbacks, fallback{
+		node:  node,
+		held:  len(p.held) - 1,
+		start: start,
+		delta: loc - start,
+		ends:  ends,
+		errs:  append([]error(nil), p.errs[errs:]...),
+	})
+	p.errs = p.errs[:errs]
+
+	return heldMark(len(p.held) - 1)
+}
+
+// walkLocations calls fn with each location set in the message and its descendants.
+func walkLocations(m protoreflect.Message, fn func(int)) {
+	m.Range(func(field protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case field.Name() == "location":
+			if v.Int() >= 0 {
+				fn(int(v.Int()))
+			}
+		case field.IsList() && field.Message() != nil:
+			for i := range v.List().Len() {
+				walkLocations(v.List().Get(i).Message(), fn)
+			}
+		case field.Message() != nil && !field.IsMap():
+			walkLocations(v.Message(), fn)
+		}
+
+		return true
+	})
+}
+
+// fallbackEnds returns the candidate ends of the source text of a node starting at start: the end of
+// the first token from last, the greatest location in the node, that closes the brackets opened since
+// start, then the ends of the tokens that follow it in the statement.
+func (p *printer) fallbackEnds(start, last int) []int {
+	if p.tokens == nil {
+		p.tokens = scanTokens(p.source)
+	}
+
+	var ends []int
+
+	depth := 0
+
+	for _, t := range p.tokens {
+		if int(t.Start) < start {
+			continue
+		}
+
+		switch t.Token {
+		case nodes.Token_SQL_COMMENT, nodes.Token_C_COMMENT:
+			continue
+		case nodes.Token_ASCII_40, nodes.Token_ASCII_91:
+			depth++
+		case nodes.Token_ASCII_41, nodes.Token_ASCII_93:
+			depth--
+		case nodes.Token_ASCII_59:
+			if depth == 0 {
+				return ends
+			}
+		}
+
+		if depth < 0 {
+			return ends
+		}
+
+		if depth == 0 && int(t.Start) >= last {
+			ends = append(ends, int(t.End))
+			if len(ends) == maxFallbackEnds {
+				return ends
+			}
+		}
+	}
+
+	return ends
+}
+
+// resolve ends the source text of each fallback left in the printed statement at the first candidate
+// for which the statement parses back to the original node, and records the copied spans.  It returns
+// the errors of the fallbacks when one cannot be resolved.
+func (p *printer) resolve(result string) []error {
+	var present []*fallback
+
+	for i := range p.fallbacks {
+		if f := &p.fallbacks[i]; strings.Contains(result, heldMark(f.held)) {
+			present = append(present, f)
+		}
+	}
+
+	for _, f := range present {
+		for !p.verify(result, f) {
+			f.end++
+			if f.end == len(f.ends) {
+				var errs []error
+				for _, f := range present {
+					errs = append(errs, f.errs...)
+				}
+
+				return errs
+			}
+
+			p.held[f.held] = p.source[f.start:f.ends[f.end]]
+		}
+	}
+
+	for _, f := range present {
+		p.passthrough = append(p.passthrough, Passthrough{
+			Statement: p.statement,
+			Start:     f.start,
+			End:       f.ends[f.end],
+			Err:       &PrintError{f.errs},
+		})
+	}
+
+	return nil
+}
+
+// verify reports whether the printed statement parses back to the node of the fallback, at the
+// position its source text is released to.
+func (p *printer) verify(result string, f *fallback) bool {
+	at := strings.Index(result, heldMark(f.held))
+
+	// the held texts preceding the fallback shift its position
+	before := p.release(result[:at])
+
+	tree, err := nodes.Parse(before + p.release(result[at:]))
+	if err != nil || len(tree.Stmts) != 1 {
+		return false
+	}
+
+	want := withoutLocations(f.node.ProtoReflect())
+	loc := int64(len(before) + f.delta)
+	found := false
+
+	walkMessages(tree.ProtoReflect(), func(m protoreflect.Message) bool {
+		field := m.Descriptor().Fields().ByName("location")
+		if field == nil || m.Get(field).Int() != loc || m.Descriptor() != want.Descriptor() {
+			return true
+		}
+
+		found = proto.Equal(withoutLocations(m).Interface(), want.Interface())
+
+		return !found
+	})
+
+	return found
+}
+
+// walkMessages calls fn with the message and its descendants until it returns false.
+func walkMessages(m protoreflect.Message, fn func(protoreflect.Message) bool) bool {
+	if !fn(m) {
+		return false
+	}
+
+	more := true
+
+	m.Range(func(field protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case field.IsList() && field.Message() != nil:
+			for i := 0; more && i < v.List().Len(); i++ {
+				more = walkMessages(v.List().Get(i).Message(), fn)
+			}
+		case field.Message() != nil && !field.IsMap():
+			more = walkMessages(v.Message(), fn)
+		}
+
+		return more
+	})
+
+	return more
+}
+
+// withoutLocations returns a copy of the message held by a Node, or of the message, with the
+// locations cleared.
+func withoutLocations(m protoreflect.Message) protoreflect.Message {
+	if oneof := m.Descriptor().Oneofs().ByName("node"); oneof != nil {
+		if field := m.WhichOneof(oneof); field != nil {
+			m = m.Get(field).Message()
+		}
+	}
+
+	result := proto.Clone(m.Interface()).ProtoReflect()
+
+	walkMessages(result, func(m protoreflect.Message) bool {
+		if field := m.Descriptor().Fields().ByName("location"); field != nil {
+			m.Clear(field)
+		}
+
+		return true
+	})
+
+	return result
+}
+
+// heldMark returns the placeholder of the held text i.
+func heldMark(i int) string {
+	return string(markHeld) + strconv.Itoa(i) + string(markHeld)
+}
@@ -3,6 +3,7 @@ package pgtree
 import (
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 type sqlBuilder struct {
@@ -132,36 +133,210 @@ func hasUpper(s string) bool {
 	return false
 }
 
+// identPosition is the syntactic position of an identifier, which decides the keywords that must be quoted.
+type identPosition int
+
+const (
+	posColID    identPosition = iota // ColId: table, column and alias names
+	posTypeFunc                      // type_function_name: unqualified function and type names
+	posLabel                         // ColLabel: column labels after AS and qualified attribute names
+)
+
+// requiresQuote returns true if s is not a valid bare identifier: it must start with a letter or
+// underscore, continue with letters, digits, underscores or `$`, and be lowercase.
 func requiresQuote(s string) bool {
-	if strings.HasPrefix(s, `"`) {
+	if s == "" || hasUpper(s) {
+		return true
+	}
+
+	for i, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r == '_', r >= utf8.RuneSelf:
+		case i > 0 && (r >= '0' && r <= '9' || r == '$'):
+		default:
+			return true
+		}
+	}
+
+	return false
+}
+
+// keywordRequiresQuote returns true if the keyword s can not be used unquoted at pos.
+func keywordRequiresQuote(s string, pos identPosition) bool {
+	k, ok := keywords[s]
+	if !ok {
 		return false
 	}
 
-	if strings.Contains(s, "-") {
-		return true
+	switch pos {
+	case posColID:
+		return k.category == ReservedKeyword || k.category == TypeFuncNameKeyword
+	case posTypeFunc:
+		return k.category == ReservedKeyword || k.category == ColNameKeyword
 	}
 
-	if strings.Contains(s, ".") {
-		return true
+	return false
+}
+
+func quoteIdentifier(s string, pos identPosition) string {
+	if hasControl(s) {
+		return unicodeQuote(s)
 	}
 
-	return hasUpper(s)
+	if requiresQuote(s) || keywordRequiresQuote(s, pos) {
+		return doubleQuote(strings.ReplaceAll(s, `"`, `""`))
+	}
+
+	return s
 }
 
-func (c *sqlBuilder) identifier(s ...string) {
-	var ss []string
+// qualifiedName quotes the parts of a dotted name, the first at pos and the rest as attribute names.
+func qualifiedName(names []string, pos identPosition) string {
+	ss := make([]string, 0, len(names))
 
-	for _, n := range trims(s) {
+	for _, n := range trims(names) {
 		if n == "" {
 			continue
 		}
 
-		if IsKeyword(n) || requiresQuote(n) {
-			ss = append(ss, doubleQuote(n))
-		} else {
-			ss = append(ss, n)
+		if len(ss) > 0 {
+			pos = posLabel
 		}
+
+		ss = append(ss, quoteIdentifier(n, pos))
+	}
+
+	return strings.Join(ss, ".")
+}
+
+func (c *sqlBuilder) identifier(s ...string) {
+	c.append(qualifiedName(s, posColID))
+}
+
+// alignStop is the granularity of aligned cell widths.  Rounding the widths up to a multiple keeps
+// the alignment unchanged when a row is added, unless it is wider than the current stop.
+const alignStop = 4
+
+// alignRows pads the cells of each row to the width of their column and joins them.  Rows with a
+// single cell, like table constraints, are not part of the alignment.
+func alignRows(rows [][]string) []string {
+	var widths []int
+
+	for _, r := range rows {
+		if len(r) < 2 {
+			continue
+		}
+
+		for i, cell := range r[:len(r)-1] {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+
+			if cell != "" {
+				// at least one space separates the cells
+				w := (utf8.RuneCountInString(cell)/alignStop + 1) * alignStop
+				widths[i] = max(widths[i], w)
+			}
+		}
+	}
+
+	result := make([]string, len(rows))
+
+	for i, r := range rows {
+		var b strings.Builder
+
+		for j, cell := range r {
+			b.WriteString(cell)
+
+			if j < len(r)-1 {
+				b.WriteString(strings.Repeat(" ", widths[j]-utf8.RuneCountInString(cell)))
+			}
+		}
+
+		result[i] = strings.TrimRight(b.String(), " ")
+	}
+
+	return result
+}
+
+// riverWidth is the gutter of the river style: clause keywords are right aligned on the end of SELECT.
+const riverWidth = 6
+
+// riverKeywords start the lines of a clause body that are aligned in the gutter like clause keywords.
+var riverKeywords = map[string]bool{
+	"AND": true, "OR": true, "JOIN": true, "INNER": true, "LEFT": true, "RIGHT": true, "FULL": true,
+	"CROSS": true, "NATURAL": true,
+}
+
+func (c *sqlBuilder) river() bool {
+	return c.Pretty && c.River
+}
+
+// clause appends a clause with its body on the indented lines below the head, or beside the head in
+// river style.
+func (c *sqlBuilder) clause(head, body string) {
+	if c.river() {
+		c.ss = append(c.ss, riverClause(head, body)+"\n")
+
+		return
+	}
+
+	c.append(head)
+	c.LF()
+	c.appendPadded(body)
+}
+
+// inlineClause appends a clause with its body on the same line as the head.
+func (c *sqlBuilder) inlineClause(head, body string) {
+	if c.river() {
+		c.ss = append(c.ss, riverClause(head, body)+"\n")
+
+		return
+	}
+
+	c.append(head, body)
+	c.LF()
+}
+
+// riverClause right aligns the first word of head in the gutter, the body continues on its right.
+func riverClause(head, body string) string {
+	lines := strings.Split(body, "\n")
+
+	var b strings.Builder
+
+	b.WriteString(riverAlign(head))
+
+	if lines[0] != "" {
+		b.WriteString(" " + lines[0])
+	}
+
+	for _, l := range lines[1:] {
+		b.WriteString("\n")
+		b.WriteString(riverContinuation(l))
+	}
+
+	return b.String()
+}
+
+func riverAlign(s string) string {
+	word, _, _ := strings.Cut(s, " ")
+
+	return strings.Repeat(" ", max(riverWidth-len(word), 0)) + s
+}
+
+// riverContinuation indents a body line to the right of the gutter, except for leading commas and
+// boolean or join keywords, which go in the gutter.
+func riverContinuation(line string) string {
+	word, _, _ := strings.Cut(line, " ")
+
+	switch {
+	case line == "":
+		return ""
+	case word == ",":
+		return strings.Repeat(" ", riverWidth-1) + line
+	case riverKeywords[strings.ToUpper(word)]:
+		return riverAlign(line)
 	}
 
-	c.append(strings.Join(ss, "."))
+	return strings.Repeat(" ", riverWidth+1) + line
 }
@@ -0,0 +1,420 @@
+package pgtree
+
+import (
+	"sort"
+	"strings"
+
+	nodes "github.com/pganalyze/pg_query_go/v6"
+)
+
+// The parse tree carries no comments, so they are restored after printing.  The scanner token
+// stream of the source is aligned with the token stream of the printed statement, and each comment
+// is reinserted next to the printed counterpart of the token it was attached to in the source:
+// comments on the same line as the preceding token trail that token, all others lead the next token.
+
+// maxAlignCells bounds the size of the alignment table before falling back to a greedy match.
+const maxAlignCells = 4_000_000
+
+type sqlToken struct {
+	start, end int32
+	text       string
+	stmt       int
+}
+
+type sqlComment struct {
+	sqlToken
+	line bool // -- comment, which runs to the end of the line
+}
+
+type insertion struct {
+	pos   int
+	skip  int // output bytes replaced by the insertion
+	text  string
+	order int
+}
+
+// restoreComments reinserts the comments of source, found in its scanner tokens, into the printed
+// statements.  Returns the concatenated output.
+func restoreComments(source string, scanned []*nodes.ScanToken, stmts []*nodes.RawStmt, printed []string, padding string,
+	verbatim []Passthrough,
+) string {
+	tokens, comments := splitTokens(source, scanned, stmts)
+	if len(comments) == 0 {
+		return strings.Join(printed, "")
+	}
+
+	offsets := make([]int, len(printed))
+	out := ""
+
+	for i, s := range printed {
+		offsets[i] = len(out)
+		out += s
+	}
+
+	// position of each source token in the output, -1 when it was not printed
+	aligned := make([]int, len(tokens))
+	for i := range aligned {
+		aligned[i] = -1
+	}
+
+	for i, s := range printed {
+		alignStatement(tokens, aligned, i, s, offsets[i])
+	}
+
+	var inserts []insertion
+
+	for order, c := range comments {
+		if copied(verbatim, c) {
+			continue
+		}
+
+		prev, next := neighbours(tokens, c.start)
+
+		var ins insertion
+
+		switch {
+		case prev >= 0 && !strings.Contains(source[tokens[prev].end:c.start], "\n"):
+			ins = trailingComment(out, tokens, aligned, prev, c, offsets, printed)
+		case next >= 0:
+			// a block comment directly followed by a token on the same line stays inline
+			inline := !c.line && !strings.Contains(source[c.end:tokens[next].start], "\n")
+			ins = leadingComment(out, tokens, aligned, next, c, inline, offsets, printed, padding)
+		default:
+			ins = insertion{pos: len(out), text: c.text + "\n"}
+			if out != "" && !strings.HasSuffix(out, "\n") {
+				ins.text = "\n" + ins.text
+			}
+		}
+
+		ins.order = order
+		inserts = append(inserts, ins)
+	}
+
+	return applyInsertions(out, inserts)
+}
+
+// copied reports whether the comment is inside a span copied verbatim, which already holds it.
+func copied(verbatim []Passthrough, c sqlComment) bool {
+	for _, v := range verbatim {
+		if int(c.start) >= v.Start && int(c.end) <= v.End {
+			return true
+		}
+	}
+
+	return false
+}
+
+// statementSpan returns the offsets of the text of the statement in source, from its first token to
+// its last, without the comments around it and its terminator.  The tokens are those of splitTokens.
+func statementSpan(source string, tokens []sqlToken, stmts []*nodes.RawStmt, stmt int) (int, int) {
+	start := int(stmts[stmt].StmtLocation)
+	end := int(stmtEnd(stmts[stmt], len(source)))
+	first, last := -1, -1
+
+	for i, t := range tokens {
+		if t.stmt == stmt && int(t.end) <= end {
+			if first < 0 {
+				first = i
+			}
+
+			last = i
+		}
+	}
+
+	if first < 0 {
+		return start, end
+	}
+
+	return int(tokens[first].start), int(tokens[last].end)
+}
+
+// scanTokens returns the scanner tokens of source, none when it cannot be scanned.
+func scanTokens(source string) []*nodes.ScanToken {
+	scan, err := nodes.Scan(source)
+	if err != nil {
+		return nil
+	}
+
+	return scan.Tokens
+}
+
+// splitTokens separates comments from the other tokens and assigns each token to its statement.
+func splitTokens(source string, scanned []*nodes.ScanToken, stmts []*nodes.RawStmt) ([]sqlToken, []sqlComment) {
+	var (
+		tokens   []sqlToken
+		comments []sqlComment
+	)
+
+	stmt := 0
+
+	for _, t := range scanned {
+		tok := sqlToken{start: t.Start, end: t.End, text: source[t.Start:t.End]}
+
+		switch t.Token {
+		case nodes.Token_SQL_COMMENT:
+			comments = append(comments, sqlComment{sqlToken: tok, line: true})
+
+			continue
+		case nodes.Token_C_COMMENT:
+			comments = append(comments, sqlComment{sqlToken: tok})
+
+			continue
+		}
+
+		for stmt < len(stmts)-1 && t.Start > stmtEnd(stmts[stmt], len(source)) {
+			stmt++
+		}
+
+		tok.stmt = stmt
+		tokens = append(tokens, tok)
+	}
+
+	return tokens, comments
+}
+
+// commentedStatements reports for each statement whether comments appear between its tokens.  Such
+// statements are not collapsed onto a single line, where the comments would read poorly.
+func commentedStatements(source string, scanned []*nodes.ScanToken, stmts []*nodes.RawStmt) []bool {
+	result := make([]bool, len(stmts))
+	tokens, comments := splitTokens(source, scanned, stmts)
+
+	for _, c := range comments {
+		prev, next := neighbours(tokens, c.start)
+		if prev >= 0 && next >= 0 && tokens[prev].stmt == tokens[next].stmt {
+			result[tokens[next].stmt] = true
+		}
+	}
+
+	return result
+}
+
+// stmtEnd returns the offset of the end of the statement, which is where its terminator is found.
+func stmtEnd(stmt *nodes.RawStmt, sourceLen int) int32 {
+	if stmt.StmtLen == 0 {
+		return int32(sourceLen)
+	}
+
+	return stmt.StmtLocation + stmt.StmtLen
+}
+
+func neighbours(tokens []sqlToken, pos int32) (int, int) {
+	next := sort.Search(len(tokens), func(i int) bool { return tokens[i].start >= pos })
+	prev := next - 1
+
+	if next >= len(tokens) {
+		next = -1
+	}
+
+	return prev, next
+}
+
+// alignStatement matches the source tokens of statement stmt with the tokens of its printed form.
+func alignStatement(tokens []sqlToken, aligned []int, stmt int, printed string, offset int) {
+	scan, err := nodes.Scan(printed)
+	if err != nil {
+		return
+	}
+
+	var src []int
+
+	for i, t := range tokens {
+		if t.stmt == stmt {
+			src = append(src, i)
+		}
+	}
+
+	out := make([]sqlToken, 0, len(scan.Tokens))
+	for _, t := range scan.Tokens {
+		out = append(out, sqlToken{start: t.Start, end: t.End, text: printed[t.Start:t.End]})
+	}
+
+	for si, oi := range matchTokens(tokens, src, out) {
+		if oi >= 0 {
+			aligned[src[si]] = int(out[oi].start) + offset
+		}
+	}
+}
+
+// matchTokens returns for each source token the index of the matching output token, or -1.  Uses
+// the longest common subsequence, or a greedy forward search for very large statements.
+func matchTokens(tokens []sqlToken, src []int, out []sqlToken) []int {
+	n, m := len(src), len(out)
+	result := make([]int, n)
+
+	for i := range result {
+		result[i] = -1
+	}
+
+	same := func(i, j int) bool { return normalizeToken(tokens[src[i]].text) == normalizeToken(out[j].text) }
+
+	if n*m > maxAlignCells {
+		j := 0
+
+		for i := 0; i < n && j < m; i++ {
+			for k := j; k < m; k++ {
+				if same(i, k) {
+					result[i] = k
+					j = k + 1
+
+					break
+				}
+			}
+		}
+
+		return result
+	}
+
+	lcs := make([][]int32, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, m+1)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case same(i, j):
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case same(i, j):
+			result[i] = j
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	return result
+}
+
+// normalizeToken folds the differences the printer introduces: keyword case and identifier quoting.
+func normalizeToken(s string) string {
+	if len(s) > 1 && s[0] == '"' && s[len(s)-1] == '"' {
+		return strings.ReplaceAll(s[1:len(s)-1], `""`, `"`)
+	}
+
+	return strings.ToLower(s)
+}
+
+func trailingComment(out string, tokens []sqlToken, aligned []int, prev int, c sqlComment, offsets []int, printed []string) insertion {
+	stmt := tokens[prev].stmt
+
+	// fall back to the closest earlier token of the statement that was printed
+	i := prev
+	for i >= 0 && tokens[i].stmt == stmt && aligned[i] < 0 {
+		i--
+	}
+
+	var pos int
+
+	if i >= 0 && tokens[i].stmt == stmt {
+		pos = aligned[i] + len(tokens[i].text)
+	} else {
+		pos = offsets[stmt] + len(strings.TrimRight(printed[stmt], "\n"))
+	}
+
+	// keep list separators and terminators with the token they follow
+	for pos < len(out) {
+		rest := strings.TrimLeft(out[pos:], " ")
+		if rest == "" || (rest[0] != ',' && rest[0] != ';') {
+			break
+		}
+
+		pos = len(out) - len(rest) + 1
+	}
+
+	ins := insertion{pos: pos, text: " " + c.text}
+	if c.line && pos < len(out) && out[pos] != '\n' {
+		// the comment runs to the end of the line, continue the output on the next one
+		ins.text += "\n" + lineIndent(out, pos)
+		ins.skip = len(out[pos:]) - len(strings.TrimLeft(out[pos:], " "))
+	}
+
+	return ins
+}
+
+func leadingComment(out string, tokens []sqlToken, aligned []int, next int, c sqlComment, inline bool,
+	offsets []int, printed []string, padding string,
+) insertion {
+	stmt := tokens[next].stmt
+
+	// fall back to the closest later token of the statement that was printed
+	i := next
+	for i < len(tokens) && tokens[i].stmt == stmt && aligned[i] < 0 {
+		i++
+	}
+
+	pos := offsets[stmt]
+	if i < len(tokens) && tokens[i].stmt == stmt {
+		pos = aligned[i]
+	}
+
+	indent := lineIndent(out, pos)
+	lineStart := strings.LastIndexByte(out[:pos], '\n') + 1
+
+	if inline {
+		return insertion{pos: pos, text: c.text + " "}
+	}
+
+	if strings.TrimSpace(out[lineStart:pos]) == "" {
+		// the token starts a line, give the comment a line of its own
+		return insertion{pos: pos, text: c.text + "\n" + indent}
+	}
+
+	if !c.line {
+		return insertion{pos: pos, text: c.text + " "}
+	}
+
+	return insertion{pos: pos, text: c.text + "\n" + indent + padding}
+}
+
+// lineIndent returns the leading whitespace of the line containing pos.
+func lineIndent(s string, pos int) string {
+	start := strings.LastIndexByte(s[:pos], '\n') + 1
+	end := start
+
+	for end < len(s) && (s[end] == ' ' || s[end] == '\t') {
+		end++
+	}
+
+	return s[start:end]
+}
+
+func applyInsertions(s string, inserts []insertion) string {
+	sort.SliceStable(inserts, func(i, j int) bool {
+		if inserts[i].pos != inserts[j].pos {
+			return inserts[i].pos < inserts[j].pos
+		}
+
+		return inserts[i].order < inserts[j].order
+	})
+
+	var b strings.Builder
+
+	last := 0
+
+	for _, ins := range inserts {
+		if ins.pos < last {
+			ins.pos = last
+		}
+
+		b.WriteString(s[last:ins.pos])
+		b.WriteString(ins.text)
+		last = ins.pos + ins.skip
+	}
+
+	b.WriteString(s[last:])
+
+	return b.String()
+}
@@ -0,0 +1,186 @@
+package pgtree
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnknownPreset is returned when a format preset name is not defined in Presets.
+const ErrUnknownPreset = pgtreeError("unknown preset")
+
+// ConfigFiles are the names of the formatter configuration files, in the order they are looked up in
+// each directory.
+var ConfigFiles = []string{".pgtree.yaml", ".pgtree.yml", ".pgtree.json"}
+
+// Presets are the named FormatOptions a configuration file can start from.
+var Presets = map[string]FormatOptions{
+	"default": DefaultFormat,
+	"compact": {},
+	"river": {
+		Pretty:                 true,
+		OneResultColumnPerLine: true,
+		Padding:                defaultPadding,
+		SimpleLen:              defaultSimpleLen,
+		River:                  true,
+	},
+	"pg_dump-like": {
+		Pretty:                 true,
+		OneResultColumnPerLine: true,
+		Padding:                defaultPadding,
+		SimpleLen:              defaultSimpleLen,
+		StatementSpacing:       1,
+		TableParenSpace:        true,
+		TypeNames:              TypeNamesVerbose,
+	},
+}
+
+// formatConfig is the content of a configuration file: the preset to start from, overridden by any
+// of the FormatOptions.
+type formatConfig struct {
+	Preset        string `json:"preset" yaml:"preset"`
+	FormatOptions `yaml:",inline"`
+}
+
+// Preset returns a copy of the named preset.
+func Preset(name string) (FormatOptions, error) {
+	opts, ok := Presets[name]
+	if !ok {
+		return FormatOptions{}, ErrUnknownPreset.Wrap(fmt.Sprintf("%s (available: %s)", name, presetNames()))
+	}
+
+	return opts, nil
+}
+
+func presetNames() string {
+	names := make([]string, 0, len(Presets))
+	for name := range Presets {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
+
+// FindConfig returns the path of the configuration file closest to dir, searching dir and then each
+// of its parents.  The path is empty when there is none.
+func FindConfig(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		for _, name := range ConfigFiles {
+			path := filepath.Join(dir, name)
+
+			info, err := os.Stat(path)
+			if err == nil && !info.IsDir() {
+				return path, nil
+			}
+
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return "", err
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+
+		dir = parent
+	}
+}
+
+// LoadConfig returns the FormatOptions of the configuration file closest to dir, or DefaultFormat
+// when there is none.
+//
+//	opts, _ := pgtree.LoadConfig(".")
+//	out, _ := pgtree.Format(sql, opts)
+func LoadConfig(dir string) (FormatOptions, error) {
+	path, err := FindConfig(dir)
+	if err != nil || path == "" {
+		return DefaultFormat, err
+	}
+
+	return ReadConfig(path)
+}
+
+// ReadConfig reads a configuration file.  The file is JSON when its extension is .json, YAML
+// otherwise.  The options start from the `preset` it names, "default" when absent, and are
+// overridden by the options it sets:
+//
+//	preset: river
+//	max_line_width: 100
+//	lower_keyword: true
+func ReadConfig(path string) (FormatOptions, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return FormatOptions{}, err
+	}
+
+	opts, err := parseConfig(data, filepath.Ext(path) == ".json")
+	if err != nil {
+		return FormatOptions{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return opts, nil
+}
+
+// parseConfig decodes the configuration twice: once to find the preset, then over the preset so the
+// options in the file replace the ones of the preset.
+func parseConfig(data []byte, isJSON bool) (FormatOptions, error) {
+	decode := func(v any) error {
+		var err error
+
+		if isJSON {
+			d := json.NewDecoder(bytes.NewReader(data))
+			d.DisallowUnknownFields()
+			err = d.Decode(v)
+		} else {
+			d := yaml.NewDecoder(bytes.NewReader(data))
+			d.KnownFields(true)
+			err = d.Decode(v)
+		}
+
+		// an empty file leaves the configuration unchanged
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		return err
+	}
+
+	var cfg formatConfig
+	if err := decode(&cfg); err != nil {
+		return FormatOptions{}, err
+	}
+
+	name := cfg.Preset
+	if name == "" {
+		name = "default"
+	}
+
+	opts, err := Preset(name)
+	if err != nil {
+		return FormatOptions{}, err
+	}
+
+	cfg = formatConfig{FormatOptions: opts}
+	if err := decode(&cfg); err != nil {
+		return FormatOptions{}, err
+	}
+
+	return cfg.FormatOptions, nil
+}
@@ -0,0 +1,137 @@
+package pgtree_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gofoji/pgtree"
+)
+
+func TestPreset(t *testing.T) {
+	opts, err := pgtree.Preset("river")
+	if err != nil {
+		t.Fatalf("Err = %v", err)
+	}
+
+	if !opts.Pretty || !opts.River {
+		t.Errorf("got %+v, want pretty river", opts)
+	}
+
+	_, err = pgtree.Preset("nope")
+	if !errors.Is(err, pgtree.ErrUnknownPreset) {
+		t.Errorf("Err = %v, want %v", err, pgtree.ErrUnknownPreset)
+	}
+}
+
+func TestPgDumpPreset(t *testing.T) {
+	// as written by pg_dump
+	const dump = `CREATE TABLE public.accounts (
+    id integer NOT NULL,
+    name character varying(100) NOT NULL,
+    balance bigint DEFAULT 0 NOT NULL,
+    active boolean DEFAULT true,
+    created_at timestamp with time zone DEFAULT now()
+);
+
+ALTER TABLE ONLY public.accounts
+    ADD CONSTRAINT accounts_pkey PRIMARY KEY (id);
+`
+
+	opts, err := pgtree.Preset("pg_dump-like")
+	if err != nil {
+		t.Fatalf("Err = %v", err)
+	}
+
+	got, err := pgtree.Format("create table public.accounts (id int not null, name varchar(100) not null, "+
+		"balance bigint default 0 not null, active boolean default true, created_at timestamp with time zone default now()); "+
+		"alter table only public.accounts add constraint accounts_pkey primary key (id);", opts)
+	if err != nil {
+		t.Fatalf("Err = %v", err)
+	}
+
+	if got != dump {
+		t.Errorf("got `%v`, want `%v`", got, dump)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	river := pgtree.Presets["river"]
+	river.MaxLineWidth = 100
+	river.LowerKeyword = true
+
+	compact := pgtree.Presets["compact"]
+	compact.Unterminated = true
+
+	defaults := pgtree.DefaultFormat
+	defaults.Padding = "\t"
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    pgtree.FormatOptions
+		err     bool
+	}{
+		{"yaml", ".pgtree.yaml", "preset: river\nmax_line_width: 100\nlower_keyword: true\n", river, false},
+		{"json", ".pgtree.json", `{"preset": "compact", "unterminated": true}`, compact, false},
+		{"default preset", ".pgtree.yml", "padding: \"\\t\"\n", defaults, false},
+		{"empty", ".pgtree.yaml", "", pgtree.DefaultFormat, false},
+		{"empty json", ".pgtree.json", "", pgtree.DefaultFormat, false},
+		{"unknown option", ".pgtree.yaml", "prety: true\n", pgtree.FormatOptions{}, true},
+		{"unknown preset", ".pgtree.json", `{"preset": "nope"}`, pgtree.FormatOptions{}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := t.TempDir()
+			dir := filepath.Join(root, "a", "b")
+
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := os.WriteFile(filepath.Join(root, test.file), []byte(test.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := pgtree.LoadConfig(dir)
+			if test.err {
+				if err == nil {
+					t.Errorf("got %+v, want error", got)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Err = %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFindConfig(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "a")
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{filepath.Join(root, ".pgtree.yaml"), filepath.Join(dir, ".pgtree.json")} {
+		if err := os.WriteFile(path, []byte("{}"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := pgtree.FindConfig(dir)
+	if err != nil || got != filepath.Join(dir, ".pgtree.json") {
+		t.Errorf("got %v, %v, want the closest file", got, err)
+	}
+}
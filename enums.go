@@ -1,6 +1,8 @@
 package pgtree
 
 import (
+	"math"
+
 	nodes "github.com/pganalyze/pg_query_go/v6"
 )
 
@@ -149,11 +151,59 @@ var PgTypeNameToKeyword = map[string]string{
 	"real":        "real",
 	"float4":      "real",
 	"float8":      "double precision",
+	"numeric":     "numeric",
+	"bpchar":      "char",
+	"varchar":     "varchar",
+	"bit":         "bit",
+	"varbit":      "bit varying",
 	"time":        "time",
 	"timetz":      "time with time zone",
 	"timestamp":   "timestamp",
 	"timestamptz": "timestamp with time zone",
 	"interval":    "interval",
+	"json":        "json",
+}
+
+// PgTypeNameToVerbose maps internal type names to the sql standard names.
+var PgTypeNameToVerbose = map[string]string{
+	"bool":        "boolean",
+	"int2":        "smallint",
+	"int4":        "integer",
+	"int8":        "bigint",
+	"float4":      "real",
+	"float8":      "double precision",
+	"numeric":     "numeric",
+	"bpchar":      "character",
+	"varchar":     "character varying",
+	"bit":         "bit",
+	"varbit":      "bit varying",
+	"time":        "time without time zone",
+	"timetz":      "time with time zone",
+	"timestamp":   "timestamp without time zone",
+	"timestamptz": "timestamp with time zone",
+	"interval":    "interval",
+	"json":        "json",
+}
+
+// PgTypeNameToConcise maps internal type names to their shortest names.
+var PgTypeNameToConcise = map[string]string{
+	"bool":        "bool",
+	"int2":        "int2",
+	"int4":        "int4",
+	"int8":        "int8",
+	"float4":      "float4",
+	"float8":      "float8",
+	"numeric":     "numeric",
+	"bpchar":      "bpchar",
+	"varchar":     "varchar",
+	"bit":         "bit",
+	"varbit":      "varbit",
+	"time":        "time",
+	"timetz":      "timetz",
+	"timestamp":   "timestamp",
+	"timestamptz": "timestamptz",
+	"interval":    "interval",
+	"json":        "json",
 }
 
 // ConstrTypeKeyword maps ConstrType enums to sql keyword.
@@ -168,6 +218,11 @@ var ConstrTypeKeyword = map[nodes.ConstrType]string{
 	nodes.ConstrType_CONSTR_FOREIGN:   "FOREIGN KEY",
 	nodes.ConstrType_CONSTR_GENERATED: "GENERATED",
 	nodes.ConstrType_CONSTR_IDENTITY:  "GENERATED",
+
+	nodes.ConstrType_CONSTR_ATTR_DEFERRABLE:     "DEFERRABLE",
+	nodes.ConstrType_CONSTR_ATTR_NOT_DEFERRABLE: "NOT DEFERRABLE",
+	nodes.ConstrType_CONSTR_ATTR_DEFERRED:       "INITIALLY DEFERRED",
+	nodes.ConstrType_CONSTR_ATTR_IMMEDIATE:      "INITIALLY IMMEDIATE",
 }
 
 // ConstraintGeneratedWhenToKeyword maps Constraint GeneratedWhen clauses to keywords.
@@ -329,3 +384,218 @@ const (
 	OperatorItemType = 1
 	FunctionItemType = 2
 )
+
+// JsonValueTypeKeyword maps JsonValueType enums to the IS JSON item type keyword.
+var JsonValueTypeKeyword = map[nodes.JsonValueType]string{
+	nodes.JsonValueType_JS_TYPE_ANY:    "",
+	nodes.JsonValueType_JS_TYPE_OBJECT: "OBJECT",
+	nodes.JsonValueType_JS_TYPE_ARRAY:  "ARRAY",
+	nodes.JsonValueType_JS_TYPE_SCALAR: "SCALAR",
+}
+
+// JsonEncodingKeyword maps JsonEncoding enums to the FORMAT JSON ENCODING name.
+var JsonEncodingKeyword = map[nodes.JsonEncoding]string{
+	nodes.JsonEncoding_JS_ENC_UTF8:  "UTF8",
+	nodes.JsonEncoding_JS_ENC_UTF16: "UTF16",
+	nodes.JsonEncoding_JS_ENC_UTF32: "UTF32",
+}
+
+// XmlExprOpKeyword maps XmlExprOp enums to the sql function name.
+var XmlExprOpKeyword = map[nodes.XmlExprOp]string{
+	nodes.XmlExprOp_IS_XMLCONCAT:    "XMLCONCAT",
+	nodes.XmlExprOp_IS_XMLELEMENT:   "XMLELEMENT",
+	nodes.XmlExprOp_IS_XMLFOREST:    "XMLFOREST",
+	nodes.XmlExprOp_IS_XMLPARSE:     "XMLPARSE",
+	nodes.XmlExprOp_IS_XMLPI:        "XMLPI",
+	nodes.XmlExprOp_IS_XMLROOT:      "XMLROOT",
+	nodes.XmlExprOp_IS_XMLSERIALIZE: "XMLSERIALIZE",
+}
+
+// XmlOptionTypeKeyword maps XmlOptionType enums to sql keyword.
+var XmlOptionTypeKeyword = map[nodes.XmlOptionType]string{
+	nodes.XmlOptionType_XMLOPTION_DOCUMENT: "DOCUMENT",
+	nodes.XmlOptionType_XMLOPTION_CONTENT:  "CONTENT",
+}
+
+// XmlStandalone enumeration of the XMLROOT standalone options.
+type XmlStandalone int32
+
+// XmlStandalone options.
+const (
+	XmlStandaloneYes XmlStandalone = iota
+	XmlStandaloneNo
+	XmlStandaloneNoValue
+	XmlStandaloneOmitted
+)
+
+// XmlStandaloneKeyword maps XmlStandalone enums to sql keyword.
+var XmlStandaloneKeyword = map[XmlStandalone]string{
+	XmlStandaloneYes:     "STANDALONE YES",
+	XmlStandaloneNo:      "STANDALONE NO",
+	XmlStandaloneNoValue: "STANDALONE NO VALUE",
+}
+
+// GroupingSetKindKeyword maps GroupingSetKind enums to sql keyword.
+var GroupingSetKindKeyword = map[nodes.GroupingSetKind]string{
+	nodes.GroupingSetKind_GROUPING_SET_ROLLUP: "ROLLUP",
+	nodes.GroupingSetKind_GROUPING_SET_CUBE:   "CUBE",
+	nodes.GroupingSetKind_GROUPING_SET_SETS:   "GROUPING SETS",
+}
+
+// TrimFuncKeyword maps the internal trim functions to the TRIM syntax keyword.
+var TrimFuncKeyword = map[string]string{
+	"btrim": "BOTH",
+	"ltrim": "LEADING",
+	"rtrim": "TRAILING",
+}
+
+// AExprKeyword returns the sql keyword for the keyword based A_Expr kinds, the operator name
+// distinguishes the negated forms (e.g. `~~` is LIKE, `!~~` is NOT LIKE).
+func AExprKeyword(kind nodes.A_Expr_Kind, op string) string {
+	switch kind {
+	case nodes.A_Expr_Kind_AEXPR_IN:
+		if op == "<>" {
+			return "NOT IN"
+		}
+
+		return "IN"
+	case nodes.A_Expr_Kind_AEXPR_LIKE:
+		if op == "!~~" {
+			return "NOT LIKE"
+		}
+
+		return "LIKE"
+	case nodes.A_Expr_Kind_AEXPR_ILIKE:
+		if op == "!~~*" {
+			return "NOT ILIKE"
+		}
+
+		return "ILIKE"
+	case nodes.A_Expr_Kind_AEXPR_SIMILAR:
+		if op == "!~" {
+			return "NOT SIMILAR TO"
+		}
+
+		return "SIMILAR TO"
+	case nodes.A_Expr_Kind_AEXPR_BETWEEN:
+		return "BETWEEN"
+	case nodes.A_Expr_Kind_AEXPR_NOT_BETWEEN:
+		return "NOT BETWEEN"
+	case nodes.A_Expr_Kind_AEXPR_BETWEEN_SYM:
+		return "BETWEEN SYMMETRIC"
+	case nodes.A_Expr_Kind_AEXPR_NOT_BETWEEN_SYM:
+		return "NOT BETWEEN SYMMETRIC"
+	}
+
+	return ""
+}
+
+// MinMaxOpKeyword maps MinMaxOp enums to sql keyword.
+var MinMaxOpKeyword = map[nodes.MinMaxOp]string{
+	nodes.MinMaxOp_IS_GREATEST: "GREATEST",
+	nodes.MinMaxOp_IS_LEAST:    "LEAST",
+}
+
+// BoolTestTypeKeyword maps BoolTestType enums to sql keyword.
+var BoolTestTypeKeyword = map[nodes.BoolTestType]string{
+	nodes.BoolTestType_IS_TRUE:        "IS TRUE",
+	nodes.BoolTestType_IS_NOT_TRUE:    "IS NOT TRUE",
+	nodes.BoolTestType_IS_FALSE:       "IS FALSE",
+	nodes.BoolTestType_IS_NOT_FALSE:   "IS NOT FALSE",
+	nodes.BoolTestType_IS_UNKNOWN:     "IS UNKNOWN",
+	nodes.BoolTestType_IS_NOT_UNKNOWN: "IS NOT UNKNOWN",
+}
+
+// RowCompareTypeOperator maps RowCompareType enums to the comparison operator.
+var RowCompareTypeOperator = map[nodes.RowCompareType]string{
+	nodes.RowCompareType_ROWCOMPARE_LT: "<",
+	nodes.RowCompareType_ROWCOMPARE_LE: "<=",
+	nodes.RowCompareType_ROWCOMPARE_EQ: "=",
+	nodes.RowCompareType_ROWCOMPARE_GE: ">=",
+	nodes.RowCompareType_ROWCOMPARE_GT: ">",
+	nodes.RowCompareType_ROWCOMPARE_NE: "<>",
+}
+
+// TableLikeOption bit flags of TableLikeClause.Options.
+type TableLikeOption uint32
+
+// TableLikeOption flags, in the order they are printed.
+const (
+	TableLikeComments TableLikeOption = 1 << iota
+	TableLikeCompression
+	TableLikeConstraints
+	TableLikeDefaults
+	TableLikeGenerated
+	TableLikeIdentity
+	TableLikeIndexes
+	TableLikeStatistics
+	TableLikeStorage
+
+	TableLikeAll TableLikeOption = math.MaxInt32
+)
+
+// TableLikeOptions lists the individual TableLikeOption flags.
+var TableLikeOptions = []TableLikeOption{
+	TableLikeComments, TableLikeCompression, TableLikeConstraints, TableLikeDefaults, TableLikeGenerated,
+	TableLikeIdentity, TableLikeIndexes, TableLikeStatistics, TableLikeStorage,
+}
+
+// TableLikeOptionKeyword maps TableLikeOption flags to sql keyword.
+var TableLikeOptionKeyword = map[TableLikeOption]string{
+	TableLikeComments:    "COMMENTS",
+	TableLikeCompression: "COMPRESSION",
+	TableLikeConstraints: "CONSTRAINTS",
+	TableLikeDefaults:    "DEFAULTS",
+	TableLikeGenerated:   "GENERATED",
+	TableLikeIdentity:    "IDENTITY",
+	TableLikeIndexes:     "INDEXES",
+	TableLikeStatistics:  "STATISTICS",
+	TableLikeStorage:     "STORAGE",
+	TableLikeAll:         "ALL",
+}
+
+// OnCommitActionKeyword maps OnCommitAction enums to sql keyword.
+var OnCommitActionKeyword = map[nodes.OnCommitAction]string{
+	nodes.OnCommitAction_ONCOMMIT_PRESERVE_ROWS: "ON COMMIT PRESERVE ROWS",
+	nodes.OnCommitAction_ONCOMMIT_DELETE_ROWS:   "ON COMMIT DELETE ROWS",
+	nodes.OnCommitAction_ONCOMMIT_DROP:          "ON COMMIT DROP",
+}
+
+// FrameOption is the bit mask describing a window frame clause.
+type FrameOption int32
+
+// Frame options.
+const (
+	FrameNonDefault FrameOption = 1 << iota
+	FrameRange
+	FrameRows
+	FrameGroups
+	FrameBetween
+	FrameStartUnboundedPreceding
+	FrameEndUnboundedPreceding
+	FrameStartUnboundedFollowing
+	FrameEndUnboundedFollowing
+	FrameStartCurrentRow
+	FrameEndCurrentRow
+	FrameStartOffsetPreceding
+	FrameEndOffsetPreceding
+	FrameStartOffsetFollowing
+	FrameEndOffsetFollowing
+	FrameExcludeCurrentRow
+	FrameExcludeGroup
+	FrameExcludeTies
+)
+
+// FrameModeKeyword maps the frame mode bits to sql keyword.
+var FrameModeKeyword = map[FrameOption]string{
+	FrameRange:  "RANGE",
+	FrameRows:   "ROWS",
+	FrameGroups: "GROUPS",
+}
+
+// FrameExcludeKeyword maps the frame exclusion bits to sql keyword.
+var FrameExcludeKeyword = map[FrameOption]string{
+	FrameExcludeCurrentRow: "EXCLUDE CURRENT ROW",
+	FrameExcludeGroup:      "EXCLUDE GROUP",
+	FrameExcludeTies:       "EXCLUDE TIES",
+}
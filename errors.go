@@ -1,8 +1,14 @@
 package pgtree
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"unicode/utf8"
+
+	nodes "github.com/pganalyze/pg_query_go/v6"
+	"github.com/pganalyze/pg_query_go/v6/parser"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 type pgtreeError string
@@ -15,20 +21,309 @@ func (err pgtreeError) Wrap(s string) error {
 	return fmt.Errorf("%s:%w", s, err)
 }
 
-const (
-	// ErrPrinter is the base error for any printer errors (generally unimplemented features).
-	ErrPrinter = pgtreeError("printer")
-)
+// ErrPrinter is the base error for any printer errors (generally unimplemented features).
+const ErrPrinter = pgtreeError("printer")
+
+// ErrNotImplemented is the error of the nodes and node options the printer does not support, it wraps
+// ErrPrinter.
+var ErrNotImplemented = &kindError{"not implemented", ErrPrinter}
+
+// kindError is a kind of a base error.
+type kindError struct {
+	msg  string
+	base error
+}
+
+func (err *kindError) Error() string {
+	return err.msg
+}
+
+func (err *kindError) Unwrap() error {
+	return err.base
+}
+
+func (err *kindError) Wrap(s string) error {
+	return fmt.Errorf("%s:%w", s, err)
+}
+
+// NodeError is an error printing a node.
+type NodeError struct {
+	Node      string // Type of the node, e.g. FuncCall.
+	Location  int    // Byte offset of the node in the source, -1 when the node has none.
+	Statement int    // Index of the statement in the parse result, -1 when printing a single node.
+	Path      string // Walk path to the node, e.g. `RawStmt > SelectStmt > targetList[2] > FuncCall`.
+	Err       error
+
+	node *nodes.Node
+}
+
+func (e *NodeError) Error() string {
+	if e.Err == ErrNotImplemented {
+		return e.Node + " not implemented"
+	}
+
+	if errors.Is(e.Err, ErrNotImplemented) {
+		// the unsupported option wrapping ErrNotImplemented follows the node
+		return e.Node + " not implemented: " + strings.TrimSuffix(e.Err.Error(), ":"+ErrNotImplemented.Error())
+	}
+
+	return e.Err.Error()
+}
+
+func (e *NodeError) Unwrap() error {
+	return e.Err
+}
 
-type printError struct {
-	errs []error
+// PrintError is returned by the printer, it holds a *NodeError for each node that failed.
+type PrintError struct {
+	Errors []error
 }
 
-func (p printError) Error() string {
-	result := make([]string, len(p.errs))
-	for i, e := range p.errs {
+func (p *PrintError) Error() string {
+	result := make([]string, len(p.Errors))
+	for i, e := range p.Errors {
 		result[i] = e.Error()
 	}
 
 	return strings.Join(result, "\n")
 }
+
+func (p *PrintError) Unwrap() []error {
+	return p.Errors
+}
+
+// locate completes the errors of the printer with the position of their node in root.  The root of
+// the statements of a parse result is their RawStmt.
+func (p *printer) locate(root *nodes.Node, errs []error) {
+	prefix := ""
+	if p.statement >= 0 {
+		prefix = "RawStmt > "
+	}
+
+	for _, err := range errs {
+		var e *NodeError
+		if !errors.As(err, &e) || e.node == nil {
+			continue
+		}
+
+		e.Statement = p.statement
+		e.Location = int(nodeLocation(e.node))
+		e.Node = nodeName(e.node.ProtoReflect())
+
+		if path, ok := nodePath(root.ProtoReflect(), e.node); ok {
+			e.Path = prefix + path
+		}
+	}
+}
+
+// nodeName returns the type of the node held by a Node, or of the message.
+func nodeName(m protoreflect.Message) string {
+	if oneof := m.Descriptor().Oneofs().ByName("node"); oneof != nil {
+		if field := m.WhichOneof(oneof); field != nil {
+			m = m.Get(field).Message()
+		}
+	}
+
+	return string(m.Descriptor().Name())
+}
+
+// nodePath returns the walk path from m to the target node.  Nodes held in a list are named by the
+// field and their index, the others by their type.
+func nodePath(m protoreflect.Message, target *nodes.Node) (string, bool) {
+	node, isNode := m.Interface().(*nodes.Node)
+	if isNode && node == target {
+		return nodeName(m), true
+	}
+
+	var (
+		path  string
+		found bool
+	)
+
+	m.Range(func(field protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if field.Message() == nil {
+			return true
+		}
+
+		if field.IsList() {
+			list := v.List()
+			for i := range list.Len() {
+				if sub, ok := nodePath(list.Get(i).Message(), target); ok {
+					path, found = subPath(fmt.Sprintf("%s[%d]", field.JSONName(), i), sub), true
+
+					return false
+				}
+			}
+
+			return true
+		}
+
+		path, found = nodePath(v.Message(), target)
+
+		return !found
+	})
+
+	// a Node only wraps the message it holds
+	if !found || isNode {
+		return path, found
+	}
+
+	return nodeName(m) + " > " + path, true
+}
+
+// subPath replaces the type of the list element that starts sub by its field and index.
+func subPath(element, sub string) string {
+	if _, rest, ok := strings.Cut(sub, " > "); ok {
+		return element + " > " + rest
+	}
+
+	return element
+}
+
+// ParseError is the error returned by Parse for invalid SQL, with the position of the error in the
+// source.
+type ParseError struct {
+	Message   string // Error message of postgres.
+	Cursorpos int    // 1-based character position of the error reported by postgres, 0 when unknown.
+	Offset    int    // Byte offset of the error in the source, -1 when unknown.
+	Line      int    // 1-based line of the error, 0 when unknown.
+	Column    int    // 1-based column of the error in characters, 0 when unknown.
+	Excerpt   string // Source line of the error.
+	Caret     string // Marker of the column under Excerpt, tabs are kept to line up with the source.
+	Context   string // Additional context reported by postgres.
+	Funcname  string // Function of postgres that raised the error, e.g. scanner_yyerror.
+	Statement int    // 0-based index of the statement holding the error, -1 when unknown.
+	Err       error  // Error returned by pg_query.
+}
+
+func (e *ParseError) Error() string {
+	return e.Message
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Diagnostic renders the error in the compiler style `file:line:col: message`, followed by the source
+// line and the caret when the position is known.
+func (e *ParseError) Diagnostic(file string) string {
+	if e.Line == 0 {
+		return file + ": " + e.Message
+	}
+
+	return fmt.Sprintf("%s:%d:%d: %s\n%s\n%s", file, e.Line, e.Column, e.Message, e.Excerpt, e.Caret)
+}
+
+// newParseError locates the error of pg_query in the source.
+func newParseError(source string, err error) error {
+	var pe *parser.Error
+	if !errors.As(err, &pe) {
+		return err
+	}
+
+	result := &ParseError{
+		Message:   pe.Message,
+		Cursorpos: pe.Cursorpos,
+		Offset:    -1,
+		Context:   pe.Context,
+		Funcname:  pe.Funcname,
+		Statement: -1,
+		Err:       err,
+	}
+
+	if pe.Cursorpos <= 0 {
+		return result
+	}
+
+	// the cursor counts characters, the end of the input is one past the last
+	offset := 0
+	for i := 1; i < pe.Cursorpos && offset < len(source); i++ {
+		_, n := utf8.DecodeRuneInString(source[offset:])
+		offset += n
+	}
+
+	start := strings.LastIndexByte(source[:offset], '\n') + 1
+
+	end := strings.IndexByte(source[offset:], '\n')
+	if end < 0 {
+		end = len(source)
+	} else {
+		end += offset
+	}
+
+	var caret strings.Builder
+
+	for _, r := range source[start:offset] {
+		if r == '\t' {
+			caret.WriteRune('\t')
+		} else {
+			caret.WriteRune(' ')
+		}
+	}
+
+	caret.WriteRune('^')
+
+	result.Offset = offset
+	result.Line = strings.Count(source[:offset], "\n") + 1
+	result.Column = utf8.RuneCountInString(source[start:offset]) + 1
+	result.Excerpt = strings.TrimSuffix(source[start:end], "\r")
+	result.Caret = caret.String()
+	result.Statement = statementIndex(source[:offset])
+
+	return result
+}
+
+// statementIndex counts the statements terminated in source, which precedes an error.  Empty
+// statements are skipped, as they are by the parser, and so are the `;` ending the statements of a
+// BEGIN ATOMIC ... END function body.
+func statementIndex(source string) int {
+	scan, err := nodes.Scan(source)
+	if err != nil {
+		return -1
+	}
+
+	var (
+		index, cases    int
+		content, atomic bool
+		prev            nodes.Token
+	)
+
+	for _, t := range scan.Tokens {
+		switch t.Token {
+		case nodes.Token_SQL_COMMENT, nodes.Token_C_COMMENT:
+			continue
+		case nodes.Token_ASCII_59:
+			if !atomic {
+				if content {
+					index++
+				}
+
+				content = false
+			}
+		case nodes.Token_ATOMIC:
+			atomic = atomic || prev == nodes.Token_BEGIN_P
+			content = true
+		case nodes.Token_CASE:
+			if atomic {
+				cases++
+			}
+
+			content = true
+		case nodes.Token_END_P:
+			// the END of a CASE expression does not close the body
+			if cases > 0 {
+				cases--
+			} else {
+				atomic = false
+			}
+
+			content = true
+		default:
+			content = true
+		}
+
+		prev = t.Token
+	}
+
+	return index
+}
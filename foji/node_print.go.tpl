@@ -22,10 +22,15 @@ func (p *printer) printNode(node *nodes.Node) (result string) {
 		return
 	}
 	p.level += 1
+	p.stack = append(p.stack, node)
+	errs := len(p.errs)
 	defer func() {
+		if p.bestEffort && len(p.errs) > errs {
+			result = p.passThrough(node, errs, result)
+		}
 		if p.debug {
 			pad := p.pad(p.level-1)
-			t := reflect.TypeOf(node)
+			t := reflect.TypeOf(node.Node)
 			name := t.Name()
 			if t.Kind() == reflect.Ptr {
 				name = t.Elem().Name()
@@ -34,6 +39,7 @@ func (p *printer) printNode(node *nodes.Node) (result string) {
 			p.debugOutput = append([]string{debugLine}, p.debugOutput...)
 		}
 		p.level -= 1
+		p.stack = p.stack[:len(p.stack)-1]
 	}()
 
 	switch n := node.Node.(type) {
@@ -95,7 +101,7 @@ func (p *printer) printNode(node *nodes.Node) (result string) {
     {{- if not (in $message "Node" "ParseResult" "ScanResult" "ScanToken") }}
 
 func (p *printer) print{{ pascal .MessageName }}(node *nodes.{{ $message }}) string {
-	p.addError(errors.New("{{ $message }} not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 	{{- end }}
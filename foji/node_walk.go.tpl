@@ -75,7 +75,7 @@ func mutateList(list []*nodes.Node, stack []*nodes.Node, v MutateFunc) {
 
 func mutate(node *nodes.Node, stack []*nodes.Node, v MutateFunc) {
 {{/*	var nodeWrapper nodes.Node*/}}
-	if node == nil || isNilValue(*node) {
+	if node == nil || isNilValue(node) {
 		return
 	}
 
@@ -4,6 +4,9 @@ go 1.23
 
 toolchain go1.23.4
 
-require github.com/pganalyze/pg_query_go/v6 v6.0.0
+require (
+	github.com/pganalyze/pg_query_go/v6 v6.0.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
-require google.golang.org/protobuf v1.36.5 // indirect
+require google.golang.org/protobuf v1.36.5
@@ -0,0 +1,81 @@
+// Command genkeywords generates keywords_gen.go from the kwlist.h shipped with pg_query_go.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const module = "github.com/pganalyze/pg_query_go/v6"
+
+var keywordLine = regexp.MustCompile(`^PG_KEYWORD\("([a-z_]+)", \w+, (\w+)_KEYWORD, (\w+)\)`)
+
+var categories = map[string]string{
+	"UNRESERVED":     "UnreservedKeyword",
+	"COL_NAME":       "ColNameKeyword",
+	"TYPE_FUNC_NAME": "TypeFuncNameKeyword",
+	"RESERVED":       "ReservedKeyword",
+}
+
+func main() {
+	out := "keywords_gen.go"
+	if len(os.Args) > 1 {
+		out = os.Args[1]
+	}
+
+	dir, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", module).Output()
+	if err != nil {
+		log.Fatalf("locating %s: %v", module, err)
+	}
+
+	f, err := os.Open(filepath.Join(strings.TrimSpace(string(dir)), "parser", "include", "postgres", "parser", "kwlist.h"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	var b bytes.Buffer
+
+	b.WriteString("// Code generated by internal/genkeywords. DO NOT EDIT.\n\n")
+	b.WriteString("package pgtree\n\n")
+	b.WriteString("// keywords lists every PostgreSQL keyword with its category and whether it may be used as a bare column label.\n")
+	b.WriteString("var keywords = map[string]keyword{\n")
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		m := keywordLine.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+
+		category, ok := categories[m[2]]
+		if !ok {
+			log.Fatalf("unknown keyword category %s", m[2])
+		}
+
+		fmt.Fprintf(&b, "%q: {%s, %t},\n", m[1], category, m[3] == "BARE_LABEL")
+	}
+
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+
+	b.WriteString("}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(out, src, 0o600); err != nil {
+		log.Fatal(err)
+	}
+}
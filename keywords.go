@@ -1,85 +1,35 @@
 package pgtree
 
-var keywords = map[string]interface{}{
-	"all":               nil,
-	"analyse":           nil,
-	"analyze":           nil,
-	"and":               nil,
-	"any":               nil,
-	"array":             nil,
-	"as":                nil,
-	"asc":               nil,
-	"asymmetric":        nil,
-	"both":              nil,
-	"case":              nil,
-	"cast":              nil,
-	"check":             nil,
-	"collate":           nil,
-	"column":            nil,
-	"constraint":        nil,
-	"create":            nil,
-	"current_date":      nil,
-	"current_role":      nil,
-	"current_time":      nil,
-	"current_timestamp": nil,
-	"current_user":      nil,
-	"default":           nil,
-	"deferrable":        nil,
-	"desc":              nil,
-	"distinct":          nil,
-	"do":                nil,
-	"else":              nil,
-	"end":               nil,
-	"except":            nil,
-	"false":             nil,
-	"for":               nil,
-	"foreign":           nil,
-	"from":              nil,
-	"grant":             nil,
-	"group":             nil,
-	"having":            nil,
-	"in":                nil,
-	"initially":         nil,
-	"intersect":         nil,
-	"into":              nil,
-	"leading":           nil,
-	"limit":             nil,
-	"localtime":         nil,
-	"localtimestamp":    nil,
-	"new":               nil,
-	"not":               nil,
-	"null":              nil,
-	"off":               nil,
-	"offset":            nil,
-	"old":               nil,
-	"on":                nil,
-	"only":              nil,
-	"or":                nil,
-	"order":             nil,
-	"placing":           nil,
-	"primary":           nil,
-	"references":        nil,
-	"select":            nil,
-	"session_user":      nil,
-	"some":              nil,
-	"symmetric":         nil,
-	"table":             nil,
-	"then":              nil,
-	"to":                nil,
-	"trailing":          nil,
-	"true":              nil,
-	"union":             nil,
-	"unique":            nil,
-	"user":              nil,
-	"using":             nil,
-	"when":              nil,
-	"where":             nil,
+//go:generate go run ./internal/genkeywords keywords_gen.go
+
+// KeywordCategory classifies keywords by where the grammar allows them as identifiers.
+type KeywordCategory int
+
+// Keyword categories, matching PostgreSQL's kwlist.h.
+const (
+	UnreservedKeyword   KeywordCategory = iota // usable as any identifier
+	ColNameKeyword                             // usable as a column or table name, not as a function or type name
+	TypeFuncNameKeyword                        // usable as a function or type name, not as a column or table name
+	ReservedKeyword                            // only usable as a column label (after AS) or attribute name
+)
+
+type keyword struct {
+	category  KeywordCategory
+	bareLabel bool
+}
+
+// LookupKeyword returns the category of `s` if it is a Postgres keyword.
+// Source: https://www.postgresql.org/docs/current/sql-keywords-appendix.html
+func LookupKeyword(s string) (KeywordCategory, bool) {
+	k, ok := keywords[s]
+
+	return k.category, ok
 }
 
-// IsKeyword returns true if `s` is a reserved keyword in Postgres
+// IsKeyword returns true if `s` is a reserved keyword in Postgres.
 // Source: reserved https://www.postgresql.org/docs/current/sql-keywords-appendix.html
 func IsKeyword(s string) bool {
-	_, ok := keywords[s]
+	k, ok := keywords[s]
 
-	return ok
+	return ok && k.category == ReservedKeyword
 }
@@ -0,0 +1,334 @@
+package pgtree
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+// With MaxLineWidth set, printers wrap the constructs that may be broken over several lines in
+// layout markers instead of choosing between spaces and newlines themselves.  Once the statement is
+// printed, layout resolves the markers in the style of Wadler/Oppen pretty printers: a group that
+// fits on the rest of the line is printed flat, otherwise its line breaks become newlines.  A broken
+// line continues at the indentation of the line the group started on, inside an indent marker one
+// level deeper.  A fill group only takes the breaks needed to keep each item on the line, and a
+// choice prints its flat alternative if it fits, its broken alternative otherwise.  Literals holding
+// newlines or control characters are replaced by placeholders until the layout is done (see hold), and
+// identifiers with control characters are unicode escaped, so every newline and marker is the printer's.
+const (
+	markGroupStart  = '\x01'
+	markGroupEnd    = '\x02'
+	markLine        = '\x03' // a space when flat
+	markSoftLine    = '\x04' // nothing when flat
+	markIndentStart = '\x05'
+	markIndentEnd   = '\x06'
+	markFillStart   = '\x07' // closed by markGroupEnd
+	markChoiceStart = '\x0e'
+	markChoiceSep   = '\x0f'
+	markChoiceEnd   = '\x10'
+)
+
+const markers = "\x01\x02\x03\x04\x05\x06\x07\x0e\x0f\x10"
+
+func (p *printer) wrapping() bool {
+	return p.MaxLineWidth > 0
+}
+
+// group marks s as a unit that is either printed flat or with all its line breaks taken.
+func (p *printer) group(s string) string {
+	if !p.wrapping() || s == "" {
+		return s
+	}
+
+	return string(markGroupStart) + s + string(markGroupEnd)
+}
+
+// fill marks s as a group whose line breaks are only taken where the next item would not fit.
+func (p *printer) fill(s string) string {
+	if !p.wrapping() || s == "" {
+		return s
+	}
+
+	return string(markFillStart) + s + string(markGroupEnd)
+}
+
+// choice selects flat when it fits on the line, broken otherwise.
+func (p *printer) choice(flat, broken string) string {
+	if !p.wrapping() {
+		return broken
+	}
+
+	return string(markChoiceStart) + flat + string(markChoiceSep) + broken + string(markChoiceEnd)
+}
+
+// indent marks s to be indented one level when its group is broken.
+func (p *printer) indent(s string) string {
+	if !p.wrapping() || s == "" {
+		return s
+	}
+
+	return string(markIndentStart) + s + string(markIndentEnd)
+}
+
+// line is a break that is printed as a space when its group fits.
+func (p *printer) line() string {
+	if !p.wrapping() {
+		return " "
+	}
+
+	return string(markLine)
+}
+
+// softLine is a break that is printed as nothing when its group fits.
+func (p *printer) softLine() string {
+	if !p.wrapping() {
+		return ""
+	}
+
+	return string(markSoftLine)
+}
+
+// wrapParens puts s between open and close.  When it does not fit, s moves to its own indented
+// lines and close to the line after.
+func (p *printer) wrapParens(open, close, s string) string {
+	if !p.wrapping() || s == "" {
+		return open + s + close
+	}
+
+	return p.group(open + p.indent(p.softLine()+s) + p.softLine() + close)
+}
+
+// joinList separates items with commas, which break onto a new line for each item when wrapped.
+func (p *printer) joinList(items []string) string {
+	return strings.Join(items, ","+p.line())
+}
+
+// wrapList renders the comma separated items between open and close, one per line if they do not fit.
+func (p *printer) wrapList(open, close string, items []string) string {
+	return p.wrapParens(open, close, p.joinList(items))
+}
+
+// fillList renders the comma separated items between open and close, with as many items on each
+// line as fit.  Used for lists of short values.
+func (p *printer) fillList(open, close string, items []string) string {
+	if !p.wrapping() || len(items) == 0 {
+		return open + strings.Join(items, ", ") + close
+	}
+
+	return p.fill(open + p.indent(p.softLine()+p.joinList(items)) + p.softLine() + close)
+}
+
+type layoutFrame struct {
+	flat   bool
+	fill   bool
+	indent string // indentation of lines broken in this frame
+	extra  string // indentation added by the layout to lines broken in this frame
+	line   int    // output line an indent frame was opened on, -1 for groups
+}
+
+type layoutPrinter struct {
+	width   int
+	padding string
+	out     []byte
+	col     int
+	line    int
+	extra   string // indentation added by the layout to the current line
+	frames  []layoutFrame
+}
+
+// layout resolves the layout markers of s for lines of at most width characters.
+func layout(s string, width int, padding string) string {
+	if !strings.ContainsAny(s, markers) {
+		return s
+	}
+
+	l := layoutPrinter{width: width, padding: padding}
+	l.frames = []layoutFrame{{line: -1}}
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case markGroupStart, markFillStart:
+			top := l.top()
+			flat := top.flat || l.fits(s[i+1:], 1, l.col)
+			l.frames = append(l.frames, layoutFrame{
+				flat: flat, fill: c == markFillStart, indent: l.lineIndent(), extra: l.extra, line: -1,
+			})
+		case markIndentStart:
+			// indents opened on the same line nest
+			top := l.top()
+			indent, extra := l.lineIndent(), l.extra
+
+			if top.line == l.line {
+				indent, extra = top.indent, top.extra
+			}
+
+			l.frames = append(l.frames, layoutFrame{
+				flat: top.flat, fill: top.fill, indent: indent + l.padding, extra: extra + l.padding, line: l.line,
+			})
+		case markGroupEnd, markIndentEnd:
+			l.frames = l.frames[:len(l.frames)-1]
+		case markChoiceStart:
+			// replace the choice by the selected alternative and continue with it
+			sep, end := choiceBounds(s, i)
+			alt := s[sep+1 : end]
+
+			if l.top().flat || l.fits(s[i+1:sep]+string(markGroupEnd)+s[end+1:], 1, l.col) {
+				alt = string(markGroupStart) + s[i+1:sep] + string(markGroupEnd)
+			}
+
+			s = s[:i] + alt + s[end+1:]
+			i--
+		case markLine, markSoftLine:
+			top := l.top()
+
+			switch {
+			case top.flat || (top.fill && l.fitsItem(c, s[i+1:])):
+				if c == markLine {
+					l.write(" ")
+				}
+			case l.lineEmpty():
+				// a break right after a newline only changes the indentation of the line
+				l.out = l.out[:l.lineStart()]
+				l.col = 0
+				l.write(top.indent)
+				l.extra = top.extra
+			default:
+				l.newline(top.indent)
+				l.extra = top.extra
+			}
+		case '\n':
+			// the line continues the innermost construct, at the indentation the layout gave it
+			l.extra = l.top().extra
+			l.newline(l.extra)
+		default:
+			r, n := utf8.DecodeRuneInString(s[i:])
+			l.out = append(l.out, s[i:i+n]...)
+			l.col++
+
+			if r == '\t' {
+				l.col += len(padding) - 1
+			}
+
+			i += n - 1
+		}
+	}
+
+	return string(l.out)
+}
+
+// choiceBounds returns the positions of the separator and the end of the choice starting at i.
+func choiceBounds(s string, i int) (int, int) {
+	depth := 0
+	sep := -1
+
+	for j := i; j < len(s); j++ {
+		switch s[j] {
+		case markChoiceStart:
+			depth++
+		case markChoiceSep:
+			if depth == 1 {
+				sep = j
+			}
+		case markChoiceEnd:
+			depth--
+			if depth == 0 {
+				return sep, j
+			}
+		}
+	}
+
+	return sep, len(s)
+}
+
+func (l *layoutPrinter) top() layoutFrame {
+	return l.frames[len(l.frames)-1]
+}
+
+func (l *layoutPrinter) write(s string) {
+	l.out = append(l.out, s...)
+	l.col += utf8.RuneCountInString(s)
+}
+
+// newline ends the current line without trailing spaces and starts the next one with indent.
+func (l *layoutPrinter) newline(indent string) {
+	l.out = append(bytes.TrimRight(l.out, " \t"), '\n')
+	l.col = 0
+	l.line++
+	l.write(indent)
+}
+
+func (l *layoutPrinter) lineStart() int {
+	return bytes.LastIndexByte(l.out, '\n') + 1
+}
+
+// lineEmpty reports whether a line was started that holds nothing but its indentation.
+func (l *layoutPrinter) lineEmpty() bool {
+	return l.line > 0 && len(bytes.TrimLeft(l.out[l.lineStart():], " \t")) == 0
+}
+
+// lineIndent returns the leading whitespace of the current output line.
+func (l *layoutPrinter) lineIndent() string {
+	start := l.lineStart()
+	end := start
+
+	for end < len(l.out) && (l.out[end] == ' ' || l.out[end] == '\t') {
+		end++
+	}
+
+	return string(l.out[start:end])
+}
+
+// fitsItem reports whether the item following a line break of a fill group fits on the current line.
+func (l *layoutPrinter) fitsItem(c byte, s string) bool {
+	col := l.col
+	if c == markLine {
+		col++
+	}
+
+	return l.fits(s, 0, col)
+}
+
+// fits reports whether the rest of the group at depth, printed flat, and the text following it up to
+// the next possible line break fit on the current line from col.  As in Wadler's algorithm, the groups
+// following the measured one may break, so any of their line breaks ends the measure.  A choice is
+// measured by its flat alternative.
+func (l *layoutPrinter) fits(s string, depth, col int) bool {
+	closed := depth <= 0
+
+	for i := 0; i < len(s); i++ {
+		switch r := s[i]; r {
+		case markChoiceStart:
+			sep, end := choiceBounds(s, i)
+			s = s[:i] + string(markGroupStart) + s[i+1:sep] + string(markGroupEnd) + s[end+1:]
+			i--
+
+			continue
+		case markGroupStart, markFillStart:
+			depth++
+		case markGroupEnd:
+			depth--
+			closed = closed || depth <= 0
+		case markIndentStart, markIndentEnd:
+		case markLine, markSoftLine:
+			if closed {
+				return true
+			}
+
+			if r == markLine {
+				col++
+			}
+		case '\n':
+			return closed
+		default:
+			_, n := utf8.DecodeRuneInString(s[i:])
+			i += n - 1
+			col++
+		}
+
+		if col > l.width {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,238 @@
+package pgtree
+
+import (
+	"strconv"
+	"strings"
+
+	nodes "github.com/pganalyze/pg_query_go/v6"
+)
+
+// literalSource maps the byte offset of a constant in the original SQL to its text as written.  Only
+// the forms that cannot be reconstructed from the parse tree are recorded: dollar quoted and unicode
+// escaped strings, and numerics (hex/octal/binary integers, underscores, exponents).  A dollar quoted
+// function body is also recorded at its AS keyword, the location of the DefElem holding it.
+type literalSource map[int32]string
+
+// scanLiterals records the spelling of the constants in sql, whose scanner tokens are tokens.
+func scanLiterals(sql string, tokens []*nodes.ScanToken) literalSource {
+	literals := literalSource{}
+
+	for i, t := range tokens {
+		text := sql[t.Start:t.End]
+
+		switch t.Token {
+		case nodes.Token_SCONST:
+			if strings.HasPrefix(text, "$") {
+				literals[t.Start] = text
+
+				if i > 0 && tokens[i-1].Token == nodes.Token_AS {
+					literals[tokens[i-1].Start] = text
+				}
+			}
+		case nodes.Token_USCONST:
+			// include a trailing UESCAPE 'c' clause, the escape character is needed to decode the string
+			if i+2 < len(tokens) && tokens[i+1].Token == nodes.Token_UESCAPE && tokens[i+2].Token == nodes.Token_SCONST {
+				text = sql[t.Start:tokens[i+2].End]
+			}
+
+			literals[t.Start] = text
+		case nodes.Token_ICONST, nodes.Token_FCONST:
+			literals[t.Start] = text
+		}
+	}
+
+	return literals
+}
+
+func (p *printer) sourceLiteral(location int32) (string, bool) {
+	if p.literals == nil || location < 0 {
+		return "", false
+	}
+
+	s, ok := p.literals[location]
+
+	return s, ok
+}
+
+func (p *printer) printStringLiteral(s string, location int32) string {
+	if src, ok := p.sourceLiteral(location); ok {
+		return p.hold(src)
+	}
+
+	if p.PreferDollarQuote && needsEscape(s) {
+		return p.hold(dollarQuote(s))
+	}
+
+	return quote(s)
+}
+
+func (p *printer) printNumericLiteral(s string, location int32) string {
+	if src, ok := p.sourceLiteral(location); ok {
+		return src
+	}
+
+	return s
+}
+
+// markHeld delimits the index of a held text in the printed statement.
+const markHeld = '\x11'
+
+// heldChars are the characters that the indentation and layout passes would change or misread.
+const heldChars = "\n\r" + markers + string(markHeld)
+
+// hold replaces text that must be printed unchanged, such as a literal spanning several lines, by a
+// placeholder that the indentation and layout passes leave alone.  release puts the text back.
+func (p *printer) hold(s string) string {
+	if !strings.ContainsAny(s, heldChars) {
+		return s
+	}
+
+	p.held = append(p.held, s)
+
+	return heldMark(len(p.held) - 1)
+}
+
+// release replaces the placeholders of s by the held texts.
+func (p *printer) release(s string) string {
+	if len(p.held) == 0 {
+		return s
+	}
+
+	var b strings.Builder
+
+	for {
+		start := strings.IndexByte(s, markHeld)
+		if start < 0 {
+			break
+		}
+
+		end := start + 1 + strings.IndexByte(s[start+1:], markHeld)
+		i, _ := strconv.Atoi(s[start+1 : end])
+
+		b.WriteString(s[:start])
+		b.WriteString(p.held[i])
+		s = s[end+1:]
+	}
+
+	b.WriteString(s)
+
+	return b.String()
+}
+
+// printBitString prints a bit string constant, stored by the parser with a leading b (binary) or x (hex).
+func printBitString(s string) string {
+	if s == "" {
+		return "B''"
+	}
+
+	switch s[0] {
+	case 'x', 'X':
+		return "X'" + s[1:] + "'"
+	case 'b', 'B':
+		return "B'" + s[1:] + "'"
+	}
+
+	return "B'" + s + "'"
+}
+
+// needsEscape returns true if s can not be written as a plain quoted literal without escaping.
+func needsEscape(s string) bool {
+	return strings.ContainsAny(s, "'\\") || hasControl(s)
+}
+
+func hasControl(s string) bool {
+	for _, r := range s {
+		if r < ' ' || r == 0x7f {
+			return true
+		}
+	}
+
+	return false
+}
+
+// quote renders s as a string literal.  Embedded quotes are doubled, and an E-prefixed escape string is
+// used when s contains backslashes or control characters.
+func quote(s string) string {
+	if !strings.Contains(s, "\\") && !hasControl(s) {
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	}
+
+	var b strings.Builder
+
+	b.WriteString("E'")
+
+	for _, r := range s {
+		switch r {
+		case '\'':
+			b.WriteString("''")
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		default:
+			// two digits, so that a following hex digit is not read as part of the escape
+			if r < ' ' || r == 0x7f {
+				hex := strconv.FormatInt(int64(r), 16)
+				b.WriteString(`\x` + strings.Repeat("0", 2-len(hex)) + hex)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+
+	b.WriteString("'")
+
+	return b.String()
+}
+
+// unicodeQuote renders s as a unicode escaped identifier, U&"d\0061t", which keeps its control
+// characters out of the printed text.
+func unicodeQuote(s string) string {
+	var b strings.Builder
+
+	b.WriteString(`U&"`)
+
+	for _, r := range s {
+		switch {
+		case r == '"':
+			b.WriteString(`""`)
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r < ' ' || r == 0x7f:
+			hex := strconv.FormatInt(int64(r), 16)
+			b.WriteString(`\` + strings.Repeat("0", 4-len(hex)) + hex)
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	b.WriteString(`"`)
+
+	return b.String()
+}
+
+// dollarQuote renders s between dollar quotes, picking a tag that does not occur in s.
+func dollarQuote(s string) string {
+	tag := dollarTag(s)
+
+	return tag + s + tag
+}
+
+// dollarTag returns the shortest tag ($$, $_$, $__$...) whose first occurrence after the opening
+// tag is the closing one.
+func dollarTag(s string) string {
+	tag := "$$"
+	for strings.Index(s+tag, tag) != len(s) {
+		tag = tag[:len(tag)-1] + "_$"
+	}
+
+	return tag
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/gofoji/pgtree"
+	nodes "github.com/pganalyze/pg_query_go/v6"
 )
 
 func TestReplaceParams(t *testing.T) {
@@ -55,3 +56,247 @@ func ExampleReplaceParams() {
 	fmt.Println(outSQL)
 	// Output: SELECT * FROM foo WHERE id = $1;
 }
+
+func TestRewrite(t *testing.T) {
+	column := func(name string) *nodes.Node {
+		return &nodes.Node{Node: &nodes.Node_ColumnRef{ColumnRef: &nodes.ColumnRef{
+			Fields: []*nodes.Node{{Node: &nodes.Node_String_{String_: &nodes.String{Sval: name}}}},
+		}}}
+	}
+	target := func(name string) *nodes.Node {
+		return &nodes.Node{Node: &nodes.Node_ResTarget{ResTarget: &nodes.ResTarget{Val: column(name)}}}
+	}
+	isTarget := func(c *pgtree.Cursor, name string) bool {
+		return c.Field() == "targetList" &&
+			pgtree.ExtractString(c.Node().GetResTarget().GetVal().GetColumnRef().GetFields(), "") == name
+	}
+
+	tests := []struct {
+		name string
+		sql  string
+		pre  pgtree.RewriteFunc
+		post pgtree.RewriteFunc
+		want string
+	}{
+		{
+			"replace", "select a, b from foo",
+			func(c *pgtree.Cursor) bool {
+				if c.Field() == "targetList" && c.Index() == 1 {
+					c.Replace(target("c"))
+				}
+
+				return true
+			}, nil, "SELECT a, c FROM foo;",
+		},
+		{
+			"delete", "select a, b, c from foo",
+			func(c *pgtree.Cursor) bool {
+				if c.Field() == "targetList" && pgtree.ExtractString(c.Node().GetResTarget().GetVal().GetColumnRef().GetFields(), "") != "c" {
+					c.Delete()
+				}
+
+				return true
+			}, nil, "SELECT c FROM foo;",
+		},
+		{
+			"insert", "select a, b from foo",
+			func(c *pgtree.Cursor) bool {
+				if c.Field() == "targetList" {
+					c.InsertBefore(target("x"))
+					c.InsertAfter(target("y"))
+				}
+
+				return true
+			}, nil, "SELECT x, a, y, x, b, y FROM foo;",
+		},
+		{
+			"delete insert before", "select a, b, c from foo",
+			func(c *pgtree.Cursor) bool {
+				if isTarget(c, "b") {
+					c.Delete()
+					c.InsertBefore(target("x"))
+					c.InsertBefore(target("y"))
+				}
+
+				return true
+			}, nil, "SELECT a, x, y, c FROM foo;",
+		},
+		{
+			"delete first insert before", "select a, b from foo",
+			func(c *pgtree.Cursor) bool {
+				if isTarget(c, "a") {
+					c.Delete()
+					c.InsertBefore(target("x"))
+				}
+
+				return true
+			}, nil, "SELECT x, b FROM foo;",
+		},
+		{
+			"delete insert after", "select a, b, c from foo",
+			func(c *pgtree.Cursor) bool {
+				if isTarget(c, "b") {
+					c.Delete()
+					c.InsertAfter(target("x"))
+				}
+
+				return true
+			}, nil, "SELECT a, x, c FROM foo;",
+		},
+		{
+			"delete replace", "select a, b, c from foo",
+			func(c *pgtree.Cursor) bool {
+				if isTarget(c, "b") || isTarget(c, "a") {
+					c.Delete()
+					c.Replace(target("x"))
+				}
+
+				return true
+			}, nil, "SELECT x, x, c FROM foo;",
+		},
+		{
+			"clear field", "select a from foo where b = 1",
+			func(c *pgtree.Cursor) bool {
+				if c.Field() == "whereClause" {
+					c.Delete()
+				}
+
+				return true
+			}, nil, "SELECT a FROM foo;",
+		},
+		{
+			"nested message", "with x as (select 1) select * from x",
+			func(c *pgtree.Cursor) bool {
+				if c.Field() == "withClause.ctes" {
+					c.Node().GetCommonTableExpr().Ctename = "y"
+				}
+
+				return true
+			}, nil, "WITH y AS ( SELECT 1 ) SELECT * FROM x;",
+		},
+		{
+			"post order", "select a + b",
+			nil, func(c *pgtree.Cursor) bool {
+				if n := c.Node().GetAExpr(); n != nil && n.Lexpr.GetColumnRef() != nil {
+					c.Replace(column("c"))
+				}
+
+				return true
+			}, "SELECT c;",
+		},
+		{
+			"skip children", "select a from (select b) x",
+			func(c *pgtree.Cursor) bool {
+				if c.Node().GetRangeSubselect() != nil {
+					return false
+				}
+
+				if c.Node().GetColumnRef() != nil {
+					c.Replace(column("c"))
+
+					return false
+				}
+
+				return true
+			}, nil, "SELECT c FROM (SELECT b) x;",
+		},
+		{
+			"stop", "select a, b",
+			nil, func(c *pgtree.Cursor) bool {
+				if c.Node().GetColumnRef() != nil {
+					c.Replace(column("c"))
+
+					return false
+				}
+
+				return true
+			}, "SELECT c, b;",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root, err := pgtree.Parse(test.sql)
+			if err != nil {
+				t.Fatalf("Parse Error = %v", err)
+			}
+
+			pgtree.Rewrite(root.Stmts[0].Stmt, test.pre, test.post)
+
+			got, err := pgtree.Print(root.Stmts[0].Stmt)
+			if err != nil {
+				t.Fatalf("Print Error = %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRewriteDeleteTwice(t *testing.T) {
+	root, _ := pgtree.Parse("select a, b from foo")
+
+	defer func() {
+		if r := recover(); r != "pgtree: Delete of a deleted node" {
+			t.Errorf("recover = %v", r)
+		}
+	}()
+
+	pgtree.Rewrite(root.Stmts[0].Stmt, func(c *pgtree.Cursor) bool {
+		if c.Field() == "targetList" && c.Index() == 1 {
+			c.Delete()
+			c.Delete()
+		}
+
+		return true
+	}, nil)
+}
+
+func TestRewritePath(t *testing.T) {
+	root, _ := pgtree.Parse("select f(a, b) from foo")
+
+	var (
+		path []pgtree.PathStep
+		post []string
+	)
+
+	pgtree.Rewrite(root.Stmts[0].Stmt, func(c *pgtree.Cursor) bool {
+		if n := c.Node().GetString_(); n != nil && n.Sval == "b" {
+			path = c.Path()
+		}
+
+		return true
+	}, func(c *pgtree.Cursor) bool {
+		if c.Node().GetColumnRef() != nil {
+			post = append(post, c.Field())
+		}
+
+		return true
+	})
+
+	want := []string{"targetList[0]", "val[-1]", "args[1]", "fields[0]"}
+	if len(path) != len(want) {
+		t.Fatalf("got %v, want %v", path, want)
+	}
+
+	for i, step := range path {
+		if got := fmt.Sprintf("%s[%d]", step.Field, step.Index); got != want[i] || step.Parent == nil {
+			t.Errorf("step %d got %s, want %s", i, got, want[i])
+		}
+	}
+
+	if fmt.Sprint(post) != "[args args]" {
+		t.Errorf("post got %v", post)
+	}
+
+	replaced := pgtree.Rewrite(root.Stmts[0].Stmt, func(c *pgtree.Cursor) bool {
+		c.Replace(&nodes.Node{Node: &nodes.Node_Integer{Integer: &nodes.Integer{Ival: 1}}})
+
+		return false
+	}, nil)
+	if replaced.GetInteger() == nil {
+		t.Errorf("root not replaced, got %v", replaced)
+	}
+}
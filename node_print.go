@@ -6,12 +6,25 @@ import (
 	"strings"
 
 	nodes "github.com/pganalyze/pg_query_go/v6"
+	"google.golang.org/protobuf/proto"
 )
 
 func (p *printer) printJoinExpr(node *nodes.JoinExpr) string {
 	b := p.builder()
 	b.append(p.printNode(node.Larg))
 	b.LF()
+	b.append(p.printJoinClause(node))
+
+	if node.Alias != nil {
+		return p.printJoinParens(b.join(" ")) + " " + p.printAlias(node.Alias)
+	}
+
+	return b.join(" ")
+}
+
+// printJoinClause prints the join of node without its left hand side.
+func (p *printer) printJoinClause(node *nodes.JoinExpr) string {
+	b := p.builder()
 
 	switch node.Jointype {
 	case nodes.JoinType_JOIN_INNER:
@@ -27,15 +40,34 @@ func (p *printer) printJoinExpr(node *nodes.JoinExpr) string {
 	case nodes.JoinType_JOIN_RIGHT:
 		b.keyword("RIGHT")
 	default:
-		p.addError(ErrPrinter.Wrap("unhandled JoinType: " + node.Jointype.String()))
+		p.addError(ErrNotImplemented.Wrap("unhandled JoinType: " + node.Jointype.String()))
 	}
 
 	b.keyword("JOIN")
-	b.append(p.printNode(node.Rarg))
+
+	// a nested join on the right hand side must be parenthesized to keep its grouping
+	if r := node.Rarg.GetJoinExpr(); r != nil && r.Alias == nil {
+		b.append(p.printJoinParens(p.printNode(node.Rarg)))
+	} else {
+		b.append(p.printNode(node.Rarg))
+	}
 
 	if node.Quals != nil {
-		b.keyword("ON")
-		b.append(p.printNode(node.Quals))
+		on := p.keyword("ON ") + p.printNode(node.Quals)
+
+		switch {
+		case !p.Pretty:
+		case p.JoinOnNewLine:
+			return b.join(" ") + "\n" + p.padLines(on)
+		case p.JoinAlignFrom:
+			// the continuation lines of the condition stay under the JOIN
+			first, rest, _ := strings.Cut(on, "\n")
+			if rest != "" {
+				on = first + "\n" + p.padLines(rest)
+			}
+		}
+
+		b.append(on)
 	}
 
 	if len(node.UsingClause) > 0 {
@@ -43,29 +75,85 @@ func (p *printer) printJoinExpr(node *nodes.JoinExpr) string {
 
 		b.keyword("USING")
 		b.append(fmt.Sprintf("(%s)", columns))
+
+		if node.JoinUsingAlias != nil {
+			b.keyword("AS")
+			b.append(p.printAlias(node.JoinUsingAlias))
+		}
 	}
 
 	return b.join(" ")
 }
 
+// splitJoins separates a chain of joins into the first relation and the join clauses that follow it.
+func (p *printer) splitJoins(node *nodes.Node) (string, []string) {
+	j := node.GetJoinExpr()
+	if j == nil || j.Alias != nil {
+		return p.printNode(node), nil
+	}
+
+	first, joins := p.splitJoins(j.Larg)
+
+	return first, append(joins, p.printJoinClause(j))
+}
+
+// printFromClause appends the FROM clause.  With JoinAlignFrom, the joins of a single relation
+// follow FROM at its indentation.
+func (p *printer) printFromClause(list []*nodes.Node, b *sqlBuilder) {
+	if !p.Pretty || !p.JoinAlignFrom || p.River || len(list) != 1 {
+		b.clause(p.keyword("FROM"), p.printLines(list))
+
+		return
+	}
+
+	first, joins := p.splitJoins(list[0])
+	b.clause(p.keyword("FROM"), first)
+
+	for _, j := range joins {
+		b.inlineClause(j, "")
+	}
+}
+
+// printJoinParens wraps a join, indenting multi-line output the same way as a sub-select.
+func (p *printer) printJoinParens(s string) string {
+	if !strings.Contains(s, "\n") {
+		return "(" + s + ")"
+	}
+
+	return "(" + strings.ReplaceAll(s, "\n", "\n"+p.pad(1)) + "\n)"
+}
+
 func (p *printer) printResTarget(node *nodes.ResTarget) string {
 	if node.Name != "" {
 		v := p.printNode(node.Val)
 		if v != "" {
-			return fmt.Sprintf("%s AS %s", v, p.identifier(node.Name))
+			return fmt.Sprintf("%s AS %s", v, quoteIdentifier(node.Name, posLabel))
 		}
 
-		return p.identifier(node.Name)
+		return p.identifier(node.Name) + p.printIndirection(node.Indirection)
 	}
 
 	return p.printNode(node.Val)
 }
 
 func (p *printer) printColumnRef(node *nodes.ColumnRef) string {
-	b := p.builder()
-	b.identifier(p.printArr(node.Fields)...)
+	names := make([]string, 0, len(node.Fields))
 
-	return b.join(" ")
+	for i, f := range node.Fields {
+		pos := posLabel
+		if i == 0 {
+			pos = posColID
+		}
+
+		switch n := f.Node.(type) {
+		case *nodes.Node_String_:
+			names = append(names, quoteIdentifier(n.String_.Sval, pos))
+		default:
+			names = append(names, p.printNode(f))
+		}
+	}
+
+	return strings.Join(names, ".")
 }
 
 func (p *printer) printWithClause(node *nodes.WithClause) string {
@@ -79,7 +167,12 @@ func (p *printer) printWithClause(node *nodes.WithClause) string {
 		result = append(result, "RECURSIVE")
 	}
 
-	subs := p.printNodes(node.Ctes, ", ")
+	sep := ", "
+	if p.Pretty && p.CTEParenNewLine {
+		sep = ",\n"
+	}
+
+	subs := p.printNodes(node.Ctes, sep)
 	result = append(result, subs)
 
 	return strings.Join(result, " ")
@@ -92,15 +185,20 @@ func (p *printer) printSelectStmt(node *nodes.SelectStmt) string {
 
 	r := p.printSelectStmtInternal(node)
 
-	if len(r) > p.SimpleLen {
+	if p.multiline || (!p.wrapping() && len(r) > p.SimpleLen) {
 		return r
 	}
 
 	p.Pretty = false
-	r = p.printSelectStmtInternal(node)
+	flat := p.printSelectStmtInternal(node)
 	p.Pretty = true
 
-	return r
+	if p.wrapping() {
+		// with a line width, the statement stays on one line whenever it fits
+		return p.choice(flat, r)
+	}
+
+	return flat
 }
 
 func (p *printer) printSelectStmtInternal(node *nodes.SelectStmt) string {
@@ -108,12 +206,26 @@ func (p *printer) printSelectStmtInternal(node *nodes.SelectStmt) string {
 	sub := p.printWithClause(node.WithClause)
 	b.append(sub)
 
+	if p.ShortTable && isTableStmt(node) {
+		if sub != "" {
+			b.LF()
+		}
+
+		b.keyword("TABLE")
+		b.append(p.printNode(node.FromClause[0]))
+
+		return b.join(" ")
+	}
+
 	if node.Op != nodes.SetOperation_SETOP_NONE {
+		op := p.keyword(SetOpKeyword[node.Op])
+		if node.All {
+			op += p.keyword(" ALL")
+		}
+
 		b.append(p.printSelectStmt(node.Larg))
 		b.LF()
-		b.keyword(SetOpKeyword[node.Op])
-		b.keywordIf("ALL", node.All)
-		b.LF()
+		b.inlineClause(op, "")
 		b.append(p.printSelectStmt(node.Rarg))
 	}
 
@@ -122,169 +234,267 @@ func (p *printer) printSelectStmtInternal(node *nodes.SelectStmt) string {
 			b.LF()
 		}
 
-		b.keyword("SELECT")
-		b.LF()
+		if distinct := p.printDistinct(node); distinct != "" && !b.river() {
+			// the DISTINCT clause has a line of its own between SELECT and the targets
+			b.keyword("SELECT")
+			b.LF()
+			b.append(distinct)
+			b.LF()
+			b.appendPadded(p.printSelectTargets(node))
+		} else {
+			b.clause(p.printSelectHead(node), p.printSelectTargets(node))
+		}
 	}
 
-	p.printSelectTargets(node, &b)
+	if node.IntoClause != nil && len(node.TargetList) > 0 {
+		b.clause(p.keyword("INTO"), p.printIntoClause(node.IntoClause))
+	}
 
 	if len(node.FromClause) > 0 {
-		b.keyword("FROM")
-		b.append(p.printCSV(node.FromClause))
+		p.printFromClause(node.FromClause, &b)
 	}
 
 	if node.WhereClause != nil {
-		b.keyword("WHERE")
-		b.LF()
-		b.appendPadded(p.printNode(node.WhereClause))
+		b.clause(p.keyword("WHERE"), p.printNode(node.WhereClause))
 	}
 
 	p.printSelectValues(node, &b)
 	p.printSelectCommonClauses(node, &b)
 
 	if len(node.LockingClause) > 0 {
-		b.append(p.printNodes(node.LockingClause, " "))
-		b.LF()
+		b.inlineClause(p.printNodes(node.LockingClause, " "), "")
 	}
 
-	if len(node.WindowClause) > 0 {
-		b.keyword("WINDOW")
-		b.append(p.printNodes(node.WindowClause, " "))
+	return b.join(" ")
+}
+
+// isTableStmt reports whether the select is `SELECT * FROM relation`, which the parser also produces
+// for `TABLE relation`.
+func isTableStmt(node *nodes.SelectStmt) bool {
+	if node.Op != nodes.SetOperation_SETOP_NONE || len(node.TargetList) != 1 || len(node.FromClause) != 1 ||
+		node.IntoClause != nil || node.WhereClause != nil || node.HavingClause != nil || len(node.DistinctClause) > 0 ||
+		len(node.GroupClause) > 0 || len(node.WindowClause) > 0 || len(node.ValuesLists) > 0 || len(node.SortClause) > 0 ||
+		node.LimitCount != nil || node.LimitOffset != nil || len(node.LockingClause) > 0 {
+		return false
 	}
 
-	return b.join(" ")
+	t := node.TargetList[0].GetResTarget()
+	if t == nil || t.Name != "" || t.Val.GetColumnRef() == nil {
+		return false
+	}
+
+	fields := t.Val.GetColumnRef().Fields
+	r := node.FromClause[0].GetRangeVar()
+
+	return len(fields) == 1 && fields[0].GetAStar() != nil && r != nil && r.Alias == nil
+}
+
+// printSelectHead prints the SELECT keyword with the DISTINCT clause.
+func (p *printer) printSelectHead(node *nodes.SelectStmt) string {
+	if distinct := p.printDistinct(node); distinct != "" {
+		return p.keyword("SELECT ") + distinct
+	}
+
+	return p.keyword("SELECT")
+}
+
+// printDistinct prints the DISTINCT clause of the select.
+func (p *printer) printDistinct(node *nodes.SelectStmt) string {
+	switch {
+	case len(node.DistinctClause) == 0:
+		return ""
+	case len(node.DistinctClause) == 1 && node.DistinctClause[0].Node == nil:
+		// a plain DISTINCT is a clause holding a single empty node
+		return p.keyword("DISTINCT")
+	}
+
+	return p.keyword("DISTINCT ON ") + p.printSubClauseInline(node.DistinctClause)
+}
+
+// printLines prints the list one item per line when pretty printing.
+func (p *printer) printLines(list []*nodes.Node) string {
+	if !p.Pretty {
+		return p.printNodes(list, ",")
+	}
+
+	return p.joinLines(p.printArr(list))
 }
 
 func (p *printer) printSelectCommonClauses(node *nodes.SelectStmt, b *sqlBuilder) {
 	if len(node.GroupClause) > 0 {
-		b.keyword("GROUP BY")
-		b.append(p.printNodes(node.GroupClause, ", "))
-		b.LF()
+		head := p.keyword("GROUP BY")
+		if node.GroupDistinct {
+			head += p.keyword(" DISTINCT")
+		}
+
+		b.inlineClause(head, p.printNodes(node.GroupClause, ", "))
 	}
 
 	if node.HavingClause != nil {
-		b.keyword("HAVING")
-		b.append(p.printNode(node.HavingClause))
-		b.LF()
+		b.inlineClause(p.keyword("HAVING"), p.printNode(node.HavingClause))
+	}
+
+	if len(node.WindowClause) > 0 {
+		b.inlineClause(p.keyword("WINDOW"), p.printNodes(node.WindowClause, ", "))
 	}
 
 	if len(node.SortClause) > 0 {
-		b.keyword("ORDER BY")
-		b.append(p.printNodes(node.SortClause, ", "))
-		b.LF()
+		b.inlineClause(p.keyword("ORDER BY"), p.printNodes(node.SortClause, ", "))
 	}
 
 	if node.LimitCount != nil {
-		b.keyword("LIMIT")
-		b.append(p.printNode(node.LimitCount))
-		b.LF()
+		b.inlineClause(p.keyword("LIMIT"), p.printNode(node.LimitCount))
 	}
 
 	if node.LimitOffset != nil {
-		b.keyword("OFFSET")
-		b.append(p.printNode(node.LimitOffset))
-		b.LF()
+		b.inlineClause(p.keyword("OFFSET"), p.printNode(node.LimitOffset))
 	}
 }
 
 func (p *printer) printSelectValues(node *nodes.SelectStmt, b *sqlBuilder) {
 	if len(node.ValuesLists) > 0 {
-		b.keyword("VALUES")
-		b.LF()
-
 		var vv []string
 		for _, nl := range node.ValuesLists {
 			vv = append(vv, fmt.Sprintf("(%s)", p.printNode(nl)))
 		}
 
-		if p.Pretty {
-			b.append(p.padLines(strings.Join(vv, ",\n")))
-		} else {
-			b.append(strings.Join(vv, ", "))
+		switch {
+		case b.river():
+			b.clause(p.keyword("VALUES"), p.joinLines(vv))
+		case p.Pretty:
+			b.keyword("VALUES")
+			b.LF()
+			b.append(p.padLines(p.joinLines(vv)))
+		default:
+			b.clause(p.keyword("VALUES"), strings.Join(vv, ", "))
 		}
 	}
 }
 
-func (p *printer) printSelectTargets(node *nodes.SelectStmt, b *sqlBuilder) {
-	if len(node.TargetList) == 0 {
-		return
-	}
-
-	if len(node.DistinctClause) > 0 {
-		b.keyword("DISTINCT ON")
-		b.append(p.printSubClauseInline(node.DistinctClause))
-		b.LF()
-	}
+func (p *printer) printSelectTargets(node *nodes.SelectStmt) string {
+	targets := p.printArr(node.TargetList)
 
-	sep := ", "
-	if p.Pretty && p.OneResultColumnPerLine {
-		sep = ",\n"
+	switch {
+	case p.Pretty && p.OneResultColumnPerLine:
+		return p.joinLines(targets)
+	case p.wrapping():
+		return p.group(p.indent(p.joinList(targets)))
 	}
 
-	b.appendPadded(strings.Join(p.printArr(node.TargetList), sep))
-
-	if node.IntoClause != nil {
-		b.keyword("INTO")
-		b.LF()
-		b.appendPadded(p.printIntoClause(node.IntoClause))
-	}
+	return strings.Join(targets, ", ")
 }
 
 func (p *printer) printAExpr(node *nodes.A_Expr) string {
-	left := p.printNode(node.Lexpr)
-	right := p.printNode(node.Rexpr)
-	op := p.printNodes(node.Name, " ")
+	prec := aExprPrecedence(node)
+	name := extractStrings(node.Name)
+	op := p.printOperator(name)
 
 	switch node.Kind {
 	case nodes.A_Expr_Kind_AEXPR_OP:
-		return fmt.Sprintf("%s %s %s", left, op, right)
+		if node.Lexpr == nil {
+			return p.printPrefixOperator(op, p.printRightOperand(node.Rexpr, prec))
+		}
+
+		return p.printLeftOperand(node.Lexpr, prec) + " " + op + " " + p.printRightOperand(node.Rexpr, prec)
 	case nodes.A_Expr_Kind_AEXPR_OP_ANY:
-		return fmt.Sprintf("%s %s ANY(%s)", left, op, right)
+		return p.printLeftOperand(node.Lexpr, prec) + " " + op + p.keyword(" ANY") + "(" + p.printNode(node.Rexpr) + ")"
+	case nodes.A_Expr_Kind_AEXPR_OP_ALL:
+		return p.printLeftOperand(node.Lexpr, prec) + " " + op + p.keyword(" ALL") + "(" + p.printNode(node.Rexpr) + ")"
+	case nodes.A_Expr_Kind_AEXPR_DISTINCT:
+		return p.printLeftOperand(node.Lexpr, prec) + p.keyword(" IS DISTINCT FROM ") + p.printRightOperand(node.Rexpr, prec)
+	case nodes.A_Expr_Kind_AEXPR_NOT_DISTINCT:
+		return p.printLeftOperand(node.Lexpr, prec) + p.keyword(" IS NOT DISTINCT FROM ") + p.printRightOperand(node.Rexpr, prec)
+	case nodes.A_Expr_Kind_AEXPR_NULLIF:
+		return p.keyword("NULLIF") + "(" + p.printNode(node.Lexpr) + ", " + p.printNode(node.Rexpr) + ")"
 	case nodes.A_Expr_Kind_AEXPR_IN:
-		if op == "=" {
-			op = "IN"
-		} else {
-			op = "NOT IN"
-		}
+		return p.printLeftOperand(node.Lexpr, prec) + " " + p.keyword(AExprKeyword(node.Kind, op)) + " " + p.printInList(node.Rexpr)
+	case nodes.A_Expr_Kind_AEXPR_LIKE, nodes.A_Expr_Kind_AEXPR_ILIKE, nodes.A_Expr_Kind_AEXPR_SIMILAR:
+		return p.printLeftOperand(node.Lexpr, prec) + " " + p.keyword(AExprKeyword(node.Kind, op)) + " " + p.printPattern(node.Rexpr, prec)
+	case nodes.A_Expr_Kind_AEXPR_BETWEEN, nodes.A_Expr_Kind_AEXPR_NOT_BETWEEN,
+		nodes.A_Expr_Kind_AEXPR_BETWEEN_SYM, nodes.A_Expr_Kind_AEXPR_NOT_BETWEEN_SYM:
+		l := node.Rexpr.Node.(*nodes.Node_List)
+		low := p.printRightOperand(l.List.Items[0], prec)
+		high := p.printRightOperand(l.List.Items[1], prec)
 
-		return fmt.Sprintf("%s %s (%s)", left, p.keyword(op), right)
-	case nodes.A_Expr_Kind_AEXPR_LIKE:
-		if op == "~~" {
-			op = "LIKE"
-		} else {
-			op = "NOT LIKE"
-		}
+		return fmt.Sprintf("%s %s %s %s %s", p.printLeftOperand(node.Lexpr, prec), p.keyword(AExprKeyword(node.Kind, op)), low, p.keyword("AND"), high)
+	}
 
-		return fmt.Sprintf("%s %s %s", left, p.keyword(op), right)
-	case nodes.A_Expr_Kind_AEXPR_ILIKE:
-		if op == "~~*" {
-			op = "ILIKE"
-		} else {
-			op = "NOT ILIKE"
-		}
+	p.addError(ErrNotImplemented.Wrap("unhandled A_Expr kind type: " + node.Kind.String()))
 
-		return fmt.Sprintf("%s %s %s", left, p.keyword(op), right)
-	case nodes.A_Expr_Kind_AEXPR_SIMILAR:
-		fc, ok := node.Rexpr.Node.(*nodes.Node_FuncCall)
-		if ok {
-			name := p.printNodes(fc.FuncCall.Funcname, ".")
-			if (name == "pg_catalog.similar_escape" || name == "pg_catalog.similar_to_escape") && len(fc.FuncCall.Args) == 1 {
-				right = p.printNode(fc.FuncCall.Args[0])
-			}
-		}
+	return ""
+}
 
-		return fmt.Sprintf("%s %s %s", left, p.keyword("SIMILAR TO"), right)
-	case nodes.A_Expr_Kind_AEXPR_BETWEEN:
-		l := node.Rexpr.Node.(*nodes.Node_List)
-		low := p.printNode(l.List.Items[0])
-		high := p.printNode(l.List.Items[1])
+// printInList prints the parenthesized right side of IN, a value list unless the parser kept a single expression.
+func (p *printer) printInList(node *nodes.Node) string {
+	if l, ok := node.Node.(*nodes.Node_List); ok {
+		return p.fillList("(", ")", p.printArr(l.List.Items))
+	}
+
+	return "(" + p.printNode(node) + ")"
+}
 
-		return fmt.Sprintf("%s %s %s %s %s", left, p.keyword("BETWEEN"), low, p.keyword("AND"), high)
+// printOperator renders an operator name, using the OPERATOR(schema.op) syntax for qualified operators.
+func (p *printer) printOperator(name []string) string {
+	if len(name) == 1 {
+		return name[0]
 	}
 
-	p.addError(ErrPrinter.Wrap("unhandled A_Expr kind type: " + node.Kind.String()))
+	for i := 0; i < len(name)-1; i++ {
+		name[i] = p.identifier(name[i])
+	}
 
-	return ""
+	return p.keyword("OPERATOR") + "(" + strings.Join(name, ".") + ")"
+}
+
+// anyOperator prints an operator name in the positions where the grammar accepts a bare
+// schema qualified operator (schema.+) rather than the OPERATOR(schema.+) form.
+func (p *printer) anyOperator(name []string) string {
+	if len(name) == 0 {
+		return ""
+	}
+
+	return qualifiedName(name[:len(name)-1], posColID) + strings.Repeat(".", min(len(name)-1, 1)) + name[len(name)-1]
+}
+
+func isOperatorName(name []string) bool {
+	if len(name) == 0 {
+		return false
+	}
+
+	op := name[len(name)-1]
+
+	return op != "" && strings.Trim(op, operatorChars) == ""
+}
+
+// printPrefixOperator joins a prefix operator to its operand, separating them when the operand
+// would otherwise be lexed as part of the operator (e.g. `- -1` or `@ -x`).
+func (p *printer) printPrefixOperator(op, operand string) string {
+	if operand == "" || strings.ContainsRune(operatorChars, rune(operand[0])) || strings.HasPrefix(op, p.keyword("OPERATOR")) {
+		return op + " " + operand
+	}
+
+	return op + operand
+}
+
+const operatorChars = "+-*/<>=~!@#%^&|`?"
+
+// printPattern renders the right side of LIKE/ILIKE/SIMILAR TO, unwrapping the escape function the parser injects.
+func (p *printer) printPattern(node *nodes.Node, prec int) string {
+	fc, ok := node.Node.(*nodes.Node_FuncCall)
+	if !ok {
+		return p.printRightOperand(node, prec)
+	}
+
+	name := strings.Join(extractStrings(fc.FuncCall.Funcname), ".")
+
+	switch {
+	case name == "pg_catalog.similar_to_escape" && len(fc.FuncCall.Args) == 1:
+		return p.printRightOperand(fc.FuncCall.Args[0], prec)
+	case (name == "pg_catalog.similar_to_escape" || name == "pg_catalog.like_escape") && len(fc.FuncCall.Args) == 2:
+		return p.printRightOperand(fc.FuncCall.Args[0], precEscape) + p.keyword(" ESCAPE ") + p.printRightOperand(fc.FuncCall.Args[1], precEscape)
+	}
+
+	return p.printRightOperand(node, prec)
 }
 
 func (p *printer) printRangeVar(node *nodes.RangeVar) string {
@@ -313,10 +523,14 @@ func (p *printer) printRangeVarInternal(node *nodes.RangeVar, ignoreInh bool) st
 }
 
 func (p *printer) printAlias(node *nodes.Alias) string {
+	if node == nil {
+		return ""
+	}
+
 	if len(node.Colnames) > 0 {
 		columns := p.printNodes(node.Colnames, ", ")
 
-		return fmt.Sprintf("%s(%s)", node.Aliasname, columns)
+		return fmt.Sprintf("%s(%s)", p.identifier(node.Aliasname), columns)
 	}
 
 	return p.identifier(node.Aliasname)
@@ -354,15 +568,15 @@ func (p *printer) printRawStmt(node *nodes.RawStmt) string {
 
 func (p *printer) printAConst(node *nodes.A_Const) string {
 	if s, ok := node.Val.(*nodes.A_Const_Sval); ok {
-		return quote(s.Sval.Sval)
+		return p.printStringLiteral(s.Sval.Sval, node.Location)
 	}
 
 	if i, ok := node.Val.(*nodes.A_Const_Ival); ok {
-		return strconv.Itoa(int(i.Ival.Ival))
+		return p.printNumericLiteral(strconv.Itoa(int(i.Ival.Ival)), node.Location)
 	}
 
 	if f, ok := node.Val.(*nodes.A_Const_Fval); ok {
-		return f.Fval.Fval
+		return p.printNumericLiteral(f.Fval.Fval, node.Location)
 	}
 
 	if b, ok := node.Val.(*nodes.A_Const_Boolval); ok {
@@ -370,14 +584,14 @@ func (p *printer) printAConst(node *nodes.A_Const) string {
 	}
 
 	if bs, ok := node.Val.(*nodes.A_Const_Bsval); ok {
-		return "B'" + bs.Bsval.Bsval[1:] + "'"
+		return printBitString(bs.Bsval.Bsval)
 	}
 
 	if node.Isnull {
 		return p.keyword("NULL")
 	}
 
-	p.addError(fmt.Errorf("unhandled A_Const type: %T", node.Val))
+	p.addError(ErrNotImplemented.Wrap(fmt.Sprintf("unhandled A_Const type: %T", node.Val)))
 	return ""
 }
 
@@ -417,23 +631,33 @@ func (p *printer) printCreateStmt(node *nodes.CreateStmt) string {
 	name := p.printRangeVar(node.Relation)
 
 	if node.OfTypename != nil {
-		name = name + p.keyword(" OF ") + p.identifier(p.printTypeName(node.OfTypename))
+		name = name + p.keyword(" OF ") + p.printTypeName(node.OfTypename)
 	}
 
 	b.append(name)
 
-	sub := p.printSubClause(node.TableElts)
-	if sub == "" {
-		// Empty table definitions are valid
+	sub := p.printColumnList(node.TableElts)
+	if sub == "" && node.OfTypename == nil {
+		// Empty table definitions are valid, except for typed tables
 		sub = "()"
 	}
 
+	if sub != "" && p.TableParenSpace {
+		sub = " " + sub
+	}
+
 	b.addToLast(sub)
 
 	if len(node.InhRelations) > 0 {
 		b.LF()
 		b.keyword("INHERITS")
-		b.append(p.printSubClauseInline(node.InhRelations))
+		b.append(p.printSubClauseInlineSpace(node.InhRelations))
+	}
+
+	if node.AccessMethod != "" {
+		b.LF()
+		b.keyword("USING")
+		b.identifier(node.AccessMethod)
 	}
 
 	if len(node.Options) > 0 {
@@ -442,10 +666,15 @@ func (p *printer) printCreateStmt(node *nodes.CreateStmt) string {
 		b.append(p.printSubClause(node.Options))
 	}
 
+	if k, ok := OnCommitActionKeyword[node.Oncommit]; ok {
+		b.LF()
+		b.keyword(k)
+	}
+
 	if node.Tablespacename != "" {
 		b.LF()
 		b.keyword("TABLESPACE")
-		b.append(node.Tablespacename)
+		b.identifier(node.Tablespacename)
 	}
 
 	return b.join(" ")
@@ -454,29 +683,19 @@ func (p *printer) printCreateStmt(node *nodes.CreateStmt) string {
 func (p *printer) printDeleteStmt(node *nodes.DeleteStmt) string {
 	b := p.builder()
 	b.append(p.printWithClause(node.WithClause))
-	b.keyword("DELETE FROM")
-	b.append(p.printRangeVar(node.Relation))
-	b.LF()
+	b.inlineClause(p.keyword("DELETE FROM"), p.printRangeVar(node.Relation))
 
 	u := p.printNodes(node.UsingClause, ", ")
 	if u != "" {
-		b.keyword("USING")
-		b.append(u)
-		b.LF()
+		b.inlineClause(p.keyword("USING"), u)
 	}
 
 	sub := p.printNode(node.WhereClause)
 	if sub != "" {
-		b.keyword("WHERE")
-		b.LF()
-		b.appendPadded(sub)
+		b.clause(p.keyword("WHERE"), sub)
 	}
 
-	r := p.printNodes(node.ReturningList, ", ")
-	if r != "" {
-		b.keyword("RETURNING")
-		b.append(r)
-	}
+	p.printReturning(node.ReturningList, &b)
 
 	return b.join(" ")
 }
@@ -485,30 +704,111 @@ func (p *printer) printColumnDef(node *nodes.ColumnDef) string {
 	b := p.builder()
 
 	b.identifier(node.Colname)
+	b.append(p.printColumnType(node))
+	b.append(p.printNodes(node.Constraints, " "))
+
+	return b.join(" ")
+}
+
+// printColumnType prints the type of a column definition with its storage options.
+func (p *printer) printColumnType(node *nodes.ColumnDef) string {
+	b := p.builder()
+
 	if node.TypeName != nil {
 		b.append(p.printTypeName(node.TypeName))
 	}
 
+	if node.StorageName != "" {
+		b.keyword("STORAGE " + node.StorageName)
+	}
+
+	if node.Compression != "" {
+		b.keyword("COMPRESSION")
+
+		if node.Compression == "default" {
+			b.keyword(node.Compression)
+		} else {
+			b.identifier(node.Compression)
+		}
+	}
+
+	if node.CollClause != nil {
+		b.keyword("COLLATE")
+		b.append(p.printCollationName(node.CollClause.Collname))
+	}
+
 	r := p.printNode(node.RawDefault)
 	if r != "" {
 		b.keyword("USING")
 		b.append(r)
 	}
 
-	b.append(p.printNodes(node.Constraints, " "))
+	return b.join(" ")
+}
 
-	if node.CollClause != nil {
-		b.keyword("COLLATE")
-		b.append(p.printArr(node.CollClause.Collname)...)
+// printColumnDefCells splits a column definition into the cells aligned by AlignColumns: the name,
+// the type, the DEFAULT, and the other constraints in their source order.
+func (p *printer) printColumnDefCells(node *nodes.ColumnDef) []string {
+	var defaults, constraints []*nodes.Node
+
+	for i, n := range node.Constraints {
+		// an attribute applies to the constraint before it, a DEFAULT followed by one stays in place
+		if isConstraint(n, nodes.ConstrType_CONSTR_DEFAULT) &&
+			(i == len(node.Constraints)-1 || !isConstraintAttr(node.Constraints[i+1])) {
+			defaults = append(defaults, n)
+		} else {
+			constraints = append(constraints, n)
+		}
 	}
 
-	return b.join(" ")
+	return []string{
+		p.identifier(node.Colname), p.printColumnType(node), p.printNodes(defaults, " "), p.printNodes(constraints, " "),
+	}
+}
+
+// isConstraint reports whether the node is a constraint of the type.
+func isConstraint(n *nodes.Node, contype nodes.ConstrType) bool {
+	c, ok := n.Node.(*nodes.Node_Constraint)
+
+	return ok && c.Constraint.Contype == contype
+}
+
+// isConstraintAttr reports whether the node is a DEFERRABLE or INITIALLY attribute of a column constraint.
+func isConstraintAttr(n *nodes.Node) bool {
+	return isConstraint(n, nodes.ConstrType_CONSTR_ATTR_DEFERRABLE) ||
+		isConstraint(n, nodes.ConstrType_CONSTR_ATTR_NOT_DEFERRABLE) ||
+		isConstraint(n, nodes.ConstrType_CONSTR_ATTR_DEFERRED) ||
+		isConstraint(n, nodes.ConstrType_CONSTR_ATTR_IMMEDIATE)
+}
+
+// printColumnList prints the elements of a table or composite type definition.  With AlignColumns,
+// the cells of the column definitions are aligned.
+func (p *printer) printColumnList(list []*nodes.Node) string {
+	if !p.Pretty || !p.AlignColumns {
+		return p.printSubClause(list)
+	}
+
+	rows := make([][]string, 0, len(list))
+
+	for _, n := range list {
+		if c, ok := n.Node.(*nodes.Node_ColumnDef); ok {
+			rows = append(rows, p.printColumnDefCells(c.ColumnDef))
+		} else if s := p.printNode(n); s != "" {
+			rows = append(rows, []string{s})
+		}
+	}
+
+	if len(rows) == 0 {
+		return ""
+	}
+
+	return "(\n" + p.padLines(p.joinLines(alignRows(rows))) + "\n)"
 }
 
 func (p *printer) printTypeName(node *nodes.TypeName) string {
 	b := p.builder()
 
-	name := p.printNodes(node.Names, ".")
+	name := p.typeName(extractStrings(node.Names)...)
 
 	if node.Setof {
 		b.keyword("SETOF")
@@ -569,25 +869,50 @@ func typeWrapper(name, args string) string {
 		return name
 	}
 
+	// the modifiers of the time types go before the time zone
+	if i := strings.Index(name, " with"); i > 0 {
+		return name[:i] + "(" + args + ")" + name[i:]
+	}
+
 	return name + "(" + args + ")"
 }
 
+// mapTypeName spells the built-in types the grammar qualifies with pg_catalog in the TypeNames style.
 func (p *printer) mapTypeName(name, args string) string {
-	if !strings.HasPrefix(name, "pg_catalog.") {
+	internal, ok := strings.CutPrefix(name, "pg_catalog.")
+	if !ok {
+		return typeWrapper(name, args)
+	}
+
+	// the modifiers of interval are printed as its fields
+	if internal == keywordInterval {
+		return keywordInterval
+	}
+
+	names := PgTypeNameToKeyword
+
+	switch {
+	case p.KeepPgCatalog:
 		return typeWrapper(name, args)
+	case p.TypeNames == TypeNamesVerbose:
+		names = PgTypeNameToVerbose
+	case p.TypeNames == TypeNamesConcise:
+		names = PgTypeNameToConcise
+	}
+
+	mapped, ok := names[internal]
+
+	if internal == "bpchar" && args == "" {
+		// char and character without a length are char(1), bpchar has no length limit
+		mapped = internal
 	}
 
-	name = name[len("pg_catalog."):]
-	switch name {
-	case "bpchar":
-		return typeWrapper("char", args)
-	case "varchar":
-		return typeWrapper("varchar", args)
-	case "numeric":
-		return typeWrapper("numeric", args)
+	if !ok {
+		// keep the qualification of types that have no keyword
+		return typeWrapper(name, args)
 	}
 
-	return PgTypeNameToKeyword[name]
+	return typeWrapper(mapped, args)
 }
 
 func (p *printer) printConstraint(node *nodes.Constraint) string {
@@ -595,11 +920,11 @@ func (p *printer) printConstraint(node *nodes.Constraint) string {
 
 	if node.Conname != "" {
 		b.keyword("CONSTRAINT")
-		b.append(node.Conname)
+		b.identifier(node.Conname)
 	}
 
 	if node.Contype == nodes.ConstrType_CONSTR_FOREIGN {
-		if len(node.FkAttrs) > 1 {
+		if len(node.FkAttrs) > 0 {
 			b.keyword("FOREIGN KEY")
 		}
 	} else {
@@ -633,13 +958,17 @@ func (p *printer) printConstraint(node *nodes.Constraint) string {
 		b.append(p.printRangeVar(node.Pktable), p.printSubClauseInlineSpace(node.PkAttrs))
 	}
 
+	// the attributes of a table constraint, those of a column constraint follow it as constraints
+	b.keywordIf("DEFERRABLE", node.Deferrable)
+	b.keywordIf("INITIALLY DEFERRED", node.Initdeferred)
+
 	if node.SkipValidation {
 		b.keyword("NOT VALID")
 	}
 
 	if node.Indexname != "" {
 		b.keyword("USING INDEX")
-		b.append(node.Indexname)
+		b.identifier(node.Indexname)
 	}
 
 	if len(node.Options) > 0 {
@@ -664,58 +993,221 @@ func (p *printer) printConstraintOptions(node *nodes.Constraint, b *sqlBuilder)
 
 func (p *printer) printConstraintExclusions(node *nodes.Constraint, b *sqlBuilder) {
 	b.keyword("USING")
-	b.append(node.AccessMethod)
+	b.identifier(node.AccessMethod)
+
+	var items []string
 
 	for _, n := range node.Exclusions {
 		nn, ok := n.Node.(*nodes.Node_List)
-		if ok {
-			b.append("(" + p.printNodes(nn.List.Items, " WITH ") + ")")
+		if ok && len(nn.List.Items) == 2 {
+			op := extractStrings(nn.List.Items[1].GetList().GetItems())
+			items = append(items, p.printNode(nn.List.Items[0])+p.keyword(" WITH ")+p.anyOperator(op))
 		}
 	}
-}
 
-func parseBool(s string) bool {
-	return s == "t" || s == "'t'"
+	b.append("(" + strings.Join(items, ", ") + ")")
 }
 
 func (p *printer) printTypeCast(node *nodes.TypeCast) string {
-	a := p.printNode(node.Arg)
-
 	t := p.printTypeName(node.TypeName)
-	if t == "boolean" {
-		return strconv.FormatBool(parseBool(a))
+
+	// 't'::boolean and 'f'::boolean are how older parsers represented TRUE and FALSE
+	if s := stringConst(node.Arg); t == "boolean" && (s == "t" || s == "f") {
+		return strconv.FormatBool(s == "t")
 	}
 
-	return a + "::" + t
-}
+	if isTypedLiteral(node) {
+		return t + " " + p.printNode(node.Arg)
+	}
 
-func (p *printer) printList(node *nodes.List) string {
-	return p.printNodes(node.Items, ", ")
+	if p.castFunction(node) {
+		return p.keyword("CAST") + "(" + p.printNode(node.Arg) + p.keyword(" AS ") + t + ")"
+	}
+
+	return p.printLeftOperand(node.Arg, precTypeCast) + "::" + t
 }
 
-func (p *printer) printFuncCall(node *nodes.FuncCall) string {
-	args := p.printNodes(node.Args, ", ")
-	if node.AggStar {
-		args = "*"
+// castFunction reports whether the cast is printed with the CAST syntax.  The location of a cast is
+// the CAST keyword, which precedes its argument, or the `::` operator, which follows it.
+func (p *printer) castFunction(node *nodes.TypeCast) bool {
+	switch p.Casts {
+	case CastsFunction:
+		return true
+	case CastsSource:
+		arg := nodeLocation(node.Arg)
+
+		return node.Location >= 0 && arg >= 0 && node.Location < arg
 	}
 
-	name := p.printNodes(node.Funcname, ".")
-	distinct := ""
+	return false
+}
 
-	if node.AggDistinct {
-		distinct = "DISTINCT "
+// nodeLocation returns the location recorded in the node, -1 when it has none.
+func nodeLocation(node *nodes.Node) int32 {
+	if node == nil || node.Node == nil {
+		return -1
 	}
 
-	result := fmt.Sprintf("%s(%s%s)", name, distinct, args)
+	m := node.ProtoReflect()
+	inner := m.Get(m.WhichOneof(m.Descriptor().Oneofs().ByName("node"))).Message()
 
-	if node.Over != nil {
-		result += p.keyword(" OVER ") + p.printWindowDef(node.Over)
+	field := inner.Descriptor().Fields().ByName("location")
+	if field == nil {
+		return -1
 	}
 
-	return result
+	return int32(inner.Get(field).Int())
 }
 
-func (p *printer) printCreateSchemaStmt(node *nodes.CreateSchemaStmt) string {
+// isTypedLiteral returns true for the `type 'literal'` syntax (date '2020-01-01'), which the parser
+// records as a cast without a location of its own, with the type name preceding the constant.
+func isTypedLiteral(node *nodes.TypeCast) bool {
+	c := node.Arg.GetAConst()
+	if c == nil || c.GetSval() == nil || node.Location >= 0 || node.TypeName == nil {
+		return false
+	}
+
+	// interval fields (interval '1' day) follow the literal, leave those as casts
+	if len(node.TypeName.Typmods) > 0 && ExtractString(node.TypeName.Names, ".") == "pg_catalog.interval" {
+		return false
+	}
+
+	return node.TypeName.Location >= 0 && node.TypeName.Location < c.Location
+}
+
+func stringConst(node *nodes.Node) string {
+	c := node.GetAConst()
+	if c == nil || c.GetSval() == nil {
+		return ""
+	}
+
+	return c.GetSval().Sval
+}
+
+func (p *printer) printList(node *nodes.List) string {
+	return p.printNodes(node.Items, ", ")
+}
+
+func (p *printer) printFuncCall(node *nodes.FuncCall) string {
+	if node.Funcformat == nodes.CoercionForm_COERCE_SQL_SYNTAX {
+		if result, ok := p.printFuncCallSQLSyntax(node); ok {
+			return result
+		}
+	}
+
+	args := p.printArr(node.Args)
+	if node.FuncVariadic && len(args) > 0 {
+		args[len(args)-1] = p.keyword("VARIADIC ") + args[len(args)-1]
+	}
+
+	if node.AggStar {
+		args = []string{"*"}
+	}
+
+	b := p.builder()
+	b.keywordIf("DISTINCT", node.AggDistinct)
+	b.append(p.joinList(args))
+
+	if len(node.AggOrder) > 0 && !node.AggWithinGroup {
+		b.keyword("ORDER BY")
+		b.append(p.printNodes(node.AggOrder, ", "))
+	}
+
+	result := p.builder()
+	result.append(p.funcName(extractStrings(node.Funcname)...) + p.wrapParens("(", ")", b.join(" ")))
+
+	if node.AggWithinGroup {
+		result.keyword("WITHIN GROUP")
+		result.append("(" + p.keyword("ORDER BY ") + p.printNodes(node.AggOrder, ", ") + ")")
+	}
+
+	p.printAggSuffix(&result, node.AggFilter, node.Over)
+
+	return result.join(" ")
+}
+
+// printAggSuffix appends the FILTER and OVER clauses that may follow an aggregate call.
+func (p *printer) printAggSuffix(b *sqlBuilder, filter *nodes.Node, over *nodes.WindowDef) {
+	if filter != nil {
+		b.keyword("FILTER")
+		b.append("(" + p.keyword("WHERE ") + p.printNode(filter) + ")")
+	}
+
+	if over != nil {
+		b.keyword("OVER")
+		b.append(p.printOver(over))
+	}
+}
+
+// printFuncCallSQLSyntax renders the functions the grammar exposes with special SQL syntax
+// (EXTRACT, SUBSTRING, TRIM...).  Returns false if the function is not recognized.
+func (p *printer) printFuncCallSQLSyntax(node *nodes.FuncCall) (string, bool) {
+	names := extractStrings(node.Funcname)
+	if len(names) != 2 || names[0] != "pg_catalog" {
+		return "", false
+	}
+
+	args := p.printArr(node.Args)
+	kw := p.keyword
+
+	switch name := names[1]; {
+	case name == "extract" && len(args) == 2:
+		return kw("EXTRACT") + "(" + p.printSQLSyntaxLabel(node.Args[0]) + kw(" FROM ") + args[1] + ")", true
+	case name == "overlay" && len(args) == 3:
+		return kw("OVERLAY") + "(" + args[0] + kw(" PLACING ") + args[1] + kw(" FROM ") + args[2] + ")", true
+	case name == "overlay" && len(args) == 4:
+		return kw("OVERLAY") + "(" + args[0] + kw(" PLACING ") + args[1] + kw(" FROM ") + args[2] + kw(" FOR ") + args[3] + ")", true
+	case name == "position" && len(args) == 2:
+		return kw("POSITION") + "(" + args[1] + kw(" IN ") + args[0] + ")", true
+	case name == "substring" && len(args) == 2:
+		return kw("SUBSTRING") + "(" + args[0] + kw(" FROM ") + args[1] + ")", true
+	case name == "substring" && len(args) == 3:
+		return kw("SUBSTRING") + "(" + args[0] + kw(" FROM ") + args[1] + kw(" FOR ") + args[2] + ")", true
+	case (name == "btrim" || name == "ltrim" || name == "rtrim") && len(args) == 1:
+		return kw("TRIM") + "(" + kw(TrimFuncKeyword[name]) + kw(" FROM ") + args[0] + ")", true
+	case (name == "btrim" || name == "ltrim" || name == "rtrim") && len(args) == 2:
+		return kw("TRIM") + "(" + kw(TrimFuncKeyword[name]) + " " + args[1] + kw(" FROM ") + args[0] + ")", true
+	case name == "pg_collation_for" && len(args) == 1:
+		return kw("COLLATION FOR") + " (" + args[0] + ")", true
+	case name == "normalize" && len(args) == 1:
+		return kw("NORMALIZE") + "(" + args[0] + ")", true
+	case name == "normalize" && len(args) == 2:
+		return kw("NORMALIZE") + "(" + args[0] + ", " + p.printSQLSyntaxLabel(node.Args[1]) + ")", true
+	case name == "is_normalized" && len(args) == 1:
+		return p.printLeftOperand(node.Args[0], precIs) + kw(" IS NORMALIZED"), true
+	case name == "is_normalized" && len(args) == 2:
+		return p.printLeftOperand(node.Args[0], precIs) + kw(" IS ") + p.printSQLSyntaxLabel(node.Args[1]) + kw(" NORMALIZED"), true
+	case name == "timezone" && len(args) == 1:
+		return p.printLeftOperand(node.Args[0], precAt) + kw(" AT LOCAL"), true
+	case name == "timezone" && len(args) == 2:
+		return p.printLeftOperand(node.Args[1], precAt) + kw(" AT TIME ZONE ") + p.printRightOperand(node.Args[0], precAt), true
+	case name == "overlaps" && len(args) == 4:
+		return "(" + args[0] + ", " + args[1] + kw(") OVERLAPS (") + args[2] + ", " + args[3] + ")", true
+	case name == "xmlexists" && len(args) == 2:
+		return kw("XMLEXISTS") + "(" + args[0] + kw(" PASSING ") + args[1] + ")", true
+	case name == "system_user" && len(args) == 0:
+		return kw("SYSTEM_USER"), true
+	}
+
+	return "", false
+}
+
+// printSQLSyntaxLabel prints the bare word arguments of special syntax functions, e.g. the YEAR in EXTRACT(YEAR FROM ...).
+func (p *printer) printSQLSyntaxLabel(node *nodes.Node) string {
+	c, ok := node.Node.(*nodes.Node_AConst)
+	if !ok {
+		return p.printNode(node)
+	}
+
+	s, ok := c.AConst.Val.(*nodes.A_Const_Sval)
+	if !ok {
+		return p.printNode(node)
+	}
+
+	return p.keyword(s.Sval.Sval)
+}
+
+func (p *printer) printCreateSchemaStmt(node *nodes.CreateSchemaStmt) string {
 	b := p.builder()
 	b.keyword("CREATE SCHEMA")
 
@@ -740,24 +1232,52 @@ func (p *printer) printCreateSchemaStmt(node *nodes.CreateSchemaStmt) string {
 }
 
 func (p *printer) printCaseExpr(node *nodes.CaseExpr) string {
+	if p.wrapping() {
+		return p.printCaseExprWrapped(node)
+	}
+
 	b := p.builder()
 	b.keyword("CASE")
 	b.append(p.printNode(node.Arg))
-	whens := p.printSpaced(node.Args)
-	b.append(whens)
+	if p.CaseOneLine {
+		b.append(p.printNodes(node.Args, " "))
+	} else {
+		b.append(p.printSpaced(node.Args))
+	}
 
 	sub := p.printNode(node.Defresult)
-	if sub != "" {
+
+	switch {
+	case sub == "":
+	case p.CaseOneLine:
+		b.append(p.keyword("ELSE ") + sub)
+	default:
 		b.appendPadded(p.keyword("ELSE ") + sub)
 	}
 
-	b.append("END")
+	b.keyword("END")
 
 	return b.join(" ")
 }
 
+// printCaseExprWrapped prints the CASE on one line, or each WHEN and the ELSE on their own indented line.
+func (p *printer) printCaseExprWrapped(node *nodes.CaseExpr) string {
+	head := p.builder()
+	head.keyword("CASE")
+	head.append(p.printNode(node.Arg))
+
+	branches := p.printArr(node.Args)
+
+	sub := p.printNode(node.Defresult)
+	if sub != "" {
+		branches = append(branches, p.keyword("ELSE ")+sub)
+	}
+
+	return p.group(head.join(" ") + p.indent(p.line()+strings.Join(branches, p.line())) + p.line() + p.keyword("END"))
+}
+
 func (p *printer) printAArrayExpr(node *nodes.A_ArrayExpr) string {
-	return fmt.Sprintf("%s[%s]", p.keyword("ARRAY"), p.printNodes(node.Elements, ", "))
+	return p.keyword("ARRAY") + p.fillList("[", "]", p.printArr(node.Elements))
 }
 
 func (p *printer) printCaseWhen(node *nodes.CaseWhen) string {
@@ -771,7 +1291,7 @@ func (p *printer) printCaseWhen(node *nodes.CaseWhen) string {
 }
 
 func (p *printer) printCoalesceExpr(node *nodes.CoalesceExpr) string {
-	return fmt.Sprintf("%s(%s)", p.keyword("COALESCE"), p.printNodes(node.Args, ", "))
+	return p.keyword("COALESCE") + p.wrapList("(", ")", p.printArr(node.Args))
 }
 
 func stripQuote(s string) string {
@@ -782,10 +1302,6 @@ func stripQuote(s string) string {
 	return s
 }
 
-func quote(s string) string {
-	return "'" + s + "'"
-}
-
 func doubleQuote(s string) string {
 	return "\"" + s + "\""
 }
@@ -827,11 +1343,11 @@ func (p *printer) printCommentStmt(node *nodes.CommentStmt) string {
 
 	switch n := node.Object.Node.(type) {
 	case *nodes.Node_String_:
-		b.append(n.String_.Sval)
+		b.identifier(n.String_.Sval)
 	case *nodes.Node_TypeName:
 		b.append(p.printTypeName(n.TypeName))
 	case *nodes.Node_List:
-		b.identifier(p.printArr(n.List.Items)...)
+		b.identifier(extractStrings(n.List.Items)...)
 	}
 
 	b.keyword("IS")
@@ -847,7 +1363,14 @@ func (p *printer) printSubClauseCustom(prefix, suffix, sep string, nodes []*node
 		sep += "\n"
 	}
 
-	sub := p.printNodes(nodes, sep)
+	var sub string
+
+	if sep == ",\n" {
+		sub = p.joinLines(p.printArr(nodes))
+	} else {
+		sub = p.printNodes(nodes, sep)
+	}
+
 	if sub == "" {
 		return ""
 	}
@@ -884,7 +1407,7 @@ func (p *printer) printCompositeTypeStmt(node *nodes.CompositeTypeStmt) string {
 	b.keyword("CREATE TYPE")
 	b.append(p.printRangeVarInternal(node.Typevar, true))
 	b.keyword("AS")
-	b.append(p.printSubClause(node.Coldeflist))
+	b.append(p.printColumnList(node.Coldeflist))
 
 	return b.join(" ")
 }
@@ -893,14 +1416,75 @@ func (p *printer) printCommonTableExpr(node *nodes.CommonTableExpr) string {
 	b := p.builder()
 	b.append(p.identifier(node.Ctename) + p.printSubClauseInlineSpace(node.Aliascolnames))
 	b.keyword("AS")
+
+	switch node.Ctematerialized {
+	case nodes.CTEMaterialize_CTEMaterializeAlways:
+		b.keyword("MATERIALIZED")
+	case nodes.CTEMaterialize_CTEMaterializeNever:
+		b.keyword("NOT MATERIALIZED")
+	}
+
+	if p.CTEParenNewLine {
+		b.LF()
+	}
+
 	b.append("(")
 	b.LF()
 	b.appendPadded(p.printNode(node.Ctequery))
 	b.append(")")
 
+	if node.SearchClause != nil {
+		b.append(p.printCtesearchClause(node.SearchClause))
+	}
+
+	if node.CycleClause != nil {
+		b.append(p.printCtecycleClause(node.CycleClause))
+	}
+
+	return b.join(" ")
+}
+
+func (p *printer) printCtesearchClause(node *nodes.CTESearchClause) string {
+	b := p.builder()
+	b.keyword("SEARCH")
+	b.keywordIfElse("BREADTH FIRST BY", "DEPTH FIRST BY", node.SearchBreadthFirst)
+	b.append(p.printNodes(node.SearchColList, ", "))
+	b.keyword("SET")
+	b.identifier(node.SearchSeqColumn)
+
+	return b.join(" ")
+}
+
+func (p *printer) printCtecycleClause(node *nodes.CTECycleClause) string {
+	b := p.builder()
+	b.keyword("CYCLE")
+	b.append(p.printNodes(node.CycleColList, ", "))
+	b.keyword("SET")
+	b.identifier(node.CycleMarkColumn)
+
+	// the grammar fills in TRUE/FALSE when TO ... DEFAULT ... is omitted
+	if !isBoolConst(node.CycleMarkValue, true) || !isBoolConst(node.CycleMarkDefault, false) {
+		b.keyword("TO")
+		b.append(p.printNode(node.CycleMarkValue))
+		b.keyword("DEFAULT")
+		b.append(p.printNode(node.CycleMarkDefault))
+	}
+
+	b.keyword("USING")
+	b.identifier(node.CyclePathColumn)
+
 	return b.join(" ")
 }
 
+func isBoolConst(node *nodes.Node, value bool) bool {
+	c := node.GetAConst()
+	if c == nil || c.GetBoolval() == nil {
+		return false
+	}
+
+	return c.GetBoolval().Boolval == value
+}
+
 func (p *printer) closeStatement(statement string) string {
 	if statement[len(statement)-1] == '\n' {
 		statement = statement[:len(statement)-1]
@@ -924,7 +1508,7 @@ func (p *printer) printAlterTableStmt(node *nodes.AlterTableStmt) string {
 	case nodes.ObjectType_OBJECT_VIEW:
 		b.keyword("VIEW")
 	default:
-		p.addError(fmt.Errorf("unknown object type %d", node.Objtype))
+		p.addError(ErrNotImplemented.Wrap("unhandled object type: " + node.Objtype.String()))
 	}
 
 	b.keywordIf("IF EXISTS", node.MissingOk)
@@ -988,7 +1572,7 @@ func (p *printer) printRenameStmt(node *nodes.RenameStmt) string {
 		nodes.ObjectType_OBJECT_VIEW, nodes.ObjectType_OBJECT_COLUMN:
 		b.append(p.printRangeVar(node.Relation))
 	case nodes.ObjectType_OBJECT_TABLESPACE, nodes.ObjectType_OBJECT_RULE, nodes.ObjectType_OBJECT_TRIGGER:
-		b.append(node.Subname)
+		b.identifier(node.Subname)
 		b.keyword("ON")
 		b.append(p.printRangeVar(node.Relation))
 	}
@@ -998,9 +1582,9 @@ func (p *printer) printRenameStmt(node *nodes.RenameStmt) string {
 	switch node.RenameType {
 	case nodes.ObjectType_OBJECT_TABCONSTRAINT, nodes.ObjectType_OBJECT_DOMCONSTRAINT:
 		b.keyword("CONSTRAINT")
-		b.append(node.Subname)
+		b.identifier(node.Subname)
 	case nodes.ObjectType_OBJECT_COLUMN:
-		b.append(node.Subname)
+		b.identifier(node.Subname)
 	}
 
 	b.keyword("TO")
@@ -1053,7 +1637,7 @@ func (p *printer) printCreateFunctionStmt(node *nodes.CreateFunctionStmt) string
 	b.keyword("CREATE")
 	b.keywordIf("OR REPLACE", node.Replace)
 	b.keyword("FUNCTION")
-	b.identifier(p.printArr(node.Funcname)...)
+	b.append(p.funcName(extractStrings(node.Funcname)...))
 
 	args := p.printSubClauseInlineSpace(node.Parameters)
 	if args == "" {
@@ -1070,7 +1654,7 @@ func (p *printer) printCreateFunctionStmt(node *nodes.CreateFunctionStmt) string
 
 func (p *printer) printFunctionParameter(node *nodes.FunctionParameter) string {
 	b := p.builder()
-	b.identifier(node.Name)
+	b.append(p.paramName(node.Name))
 	t := p.printTypeName(node.ArgType)
 
 	d := p.printNode(node.Defexpr)
@@ -1138,11 +1722,19 @@ func (p *printer) printDefElem(node *nodes.DefElem) string {
 			wrapper = "\n"
 		}
 
-		if strings.Contains(arg, "'") {
-			return p.keyword("AS") + wrapper + "$$" + wrapper + stripQuote(arg) + wrapper + "$$"
+		body := defElemStrings(node.Arg)
+		if len(body) == 1 {
+			// the body is kept as written, or dollar quoted when it would need escaping
+			if src, ok := p.sourceLiteral(node.Location); ok {
+				return p.keyword("AS") + wrapper + p.hold(src)
+			}
+
+			if needsEscape(body[0]) {
+				return p.keyword("AS") + wrapper + p.hold(dollarQuote(body[0]))
+			}
 		}
 
-		return p.keyword("AS") + wrapper + quote(stripQuote(arg))
+		return p.keyword("AS") + wrapper + strings.Join(quoted(body), ", ")
 	case "language":
 		return p.keyword("LANGUAGE ") + arg
 	case "analyze", "verbose", "costs", "settings", "buffers", "wal", "timing", "summary", "user_catalog_table", "strict":
@@ -1150,11 +1742,11 @@ func (p *printer) printDefElem(node *nodes.DefElem) string {
 	case "format":
 		return p.keyword("FORMAT " + arg)
 	case "schema":
-		return p.keyword("SCHEMA ") + p.identifier(arg)
+		return p.keyword("SCHEMA ") + arg
 	case "new_version":
-		return p.keyword("VERSION ") + p.identifier(arg)
+		return p.keyword("VERSION ") + arg
 	case "old_version":
-		return p.keyword("FROM ") + p.identifier(arg)
+		return p.keyword("FROM ") + arg
 	case "start":
 		return p.keyword("START WITH ") + arg
 	case "increment":
@@ -1170,11 +1762,19 @@ func (p *printer) printDefElem(node *nodes.DefElem) string {
 	return p.keyword(arg)
 }
 
+// defElemStrings returns the raw values of a string or list of strings DefElem argument.
+func defElemStrings(node *nodes.Node) []string {
+	if l := node.GetList(); l != nil {
+		return extractStrings(l.Items)
+	}
+
+	return extractStrings([]*nodes.Node{node})
+}
+
 func (p *printer) printBinaryList(nn []*nodes.Node, sep string, invert bool) string {
 	list := nn[0].Node.(*nodes.Node_List)
-	o := p.printArr(list.List.Items)
-	left := o[0]
-	right := p.identifier(o[1:]...)
+	left := p.printNode(list.List.Items[0])
+	right := p.identifier(extractStrings(list.List.Items[1:])...)
 
 	if invert {
 		return right + " " + sep + " " + left
@@ -1214,7 +1814,11 @@ func (p *printer) printDropStmt(node *nodes.DropStmt) string {
 
 func (p *printer) printObjectWithArgs(node *nodes.ObjectWithArgs) string {
 	b := p.builder()
-	b.identifier(p.printArr(node.Objname)...)
+	if names := extractStrings(node.Objname); isOperatorName(names) {
+		b.append(p.anyOperator(names))
+	} else {
+		b.append(p.funcName(names...))
+	}
 
 	if !node.ArgsUnspecified {
 		b.append(p.printSubClauseInlineSpace(node.Objargs))
@@ -1228,20 +1832,22 @@ func (p *printer) printInsertStmt(node *nodes.InsertStmt) string {
 	b.append(p.printWithClause(node.WithClause))
 	b.keyword("INSERT INTO")
 	b.append(p.printRangeVar(node.Relation) + p.printSubClauseInlineSpace(node.Cols))
-	b.append(p.printNode(node.SelectStmt))
 
-	r := p.printNodes(node.ReturningList, ", ")
-	if r != "" {
-		b.keyword("RETURNING")
-		b.append(r)
+	sel := p.printNode(node.SelectStmt)
+	if b.river() && strings.Contains(sel, "\n") {
+		// the river of the query continues the one of the INSERT
+		b.LF()
 	}
 
+	b.append(sel)
+	p.printReturning(node.ReturningList, &b)
+
 	return b.join(" ")
 }
 
 func (p *printer) printNamedArgExpr(node *nodes.NamedArgExpr) string {
 	b := p.builder()
-	b.identifier(node.Name)
+	b.append(p.paramName(node.Name))
 	b.keyword("=>")
 	b.append(p.printNode(node.Arg))
 
@@ -1249,40 +1855,152 @@ func (p *printer) printNamedArgExpr(node *nodes.NamedArgExpr) string {
 }
 
 func (p *printer) printSubLink(node *nodes.SubLink) string {
-	sub := "(" + p.printNode(node.Subselect) + ")"
+	sub := p.printSubquery(node.Subselect)
+	prec := nodePrecedence(&nodes.Node{Node: &nodes.Node_SubLink{SubLink: node}})
 
 	switch node.SubLinkType {
 	case nodes.SubLinkType_ANY_SUBLINK:
-		return p.printNode(node.Testexpr) + p.keyword(" IN ") + sub
+		if len(node.OperName) == 0 {
+			return p.printLeftOperand(node.Testexpr, prec) + p.keyword(" IN ") + sub
+		}
+
+		return p.printLeftOperand(node.Testexpr, prec) + " " + p.printOperator(extractStrings(node.OperName)) + p.keyword(" ANY ") + sub
 	case nodes.SubLinkType_ALL_SUBLINK:
-		return p.printNode(node.Testexpr) + " " + p.printNodes(node.OperName, " ") + p.keyword(" ALL ") + sub
+		return p.printLeftOperand(node.Testexpr, prec) + " " + p.printOperator(extractStrings(node.OperName)) + p.keyword(" ALL ") + sub
 	case nodes.SubLinkType_EXISTS_SUBLINK:
 		return p.keyword("EXISTS") + sub
+	case nodes.SubLinkType_ARRAY_SUBLINK:
+		return p.keyword("ARRAY") + sub
 	default:
 		return sub
 	}
 }
 
+// foldBetween replaces the consecutive arguments of node that are the expansion of a BETWEEN, `x >= a
+// AND x <= b`, or of a NOT BETWEEN, `x < a OR x > b`, by the BETWEEN.
+func (p *printer) foldBetween(node *nodes.BoolExpr) []*nodes.Node {
+	low, high, kind := ">=", "<=", nodes.A_Expr_Kind_AEXPR_BETWEEN
+	if node.Boolop == nodes.BoolExprType_OR_EXPR {
+		low, high, kind = "<", ">", nodes.A_Expr_Kind_AEXPR_NOT_BETWEEN
+	}
+
+	result := make([]*nodes.Node, 0, len(node.Args))
+
+	for i := 0; i < len(node.Args); i++ {
+		if i+1 < len(node.Args) {
+			l, h := comparison(node.Args[i], low), comparison(node.Args[i+1], high)
+
+			if l != nil && h != nil && sameExpr(l.Lexpr, h.Lexpr) {
+				between := &nodes.A_Expr{
+					Kind:     kind,
+					Name:     []*nodes.Node{nodes.MakeStrNode(AExprKeyword(kind, ""))},
+					Lexpr:    l.Lexpr,
+					Rexpr:    nodes.MakeListNode([]*nodes.Node{l.Rexpr, h.Rexpr}),
+					Location: l.Location,
+				}
+				result = append(result, &nodes.Node{Node: &nodes.Node_AExpr{AExpr: between}})
+				i++
+
+				continue
+			}
+		}
+
+		result = append(result, node.Args[i])
+	}
+
+	return result
+}
+
+// sameExpr reports whether the expressions are equal, regardless of their locations.
+func sameExpr(a, b *nodes.Node) bool {
+	return proto.Equal(withoutLocations(a.ProtoReflect()).Interface(), withoutLocations(b.ProtoReflect()).Interface())
+}
+
+// comparison returns the node if it is a binary expression of the unqualified operator op.
+func comparison(node *nodes.Node, op string) *nodes.A_Expr {
+	e := node.GetAExpr()
+	if e == nil || e.Kind != nodes.A_Expr_Kind_AEXPR_OP || e.Lexpr == nil || ExtractString(e.Name, ".") != op {
+		return nil
+	}
+
+	return e
+}
+
+// printSubquery parenthesizes a sub-select.  With SubqueryIndent, a multi-line sub-select starts on
+// the line after the opening parenthesis, indented by SubqueryIndent levels.
+func (p *printer) printSubquery(node *nodes.Node) string {
+	s := p.printNode(node)
+
+	if !p.Pretty || p.SubqueryIndent <= 0 || !strings.Contains(s, "\n") {
+		return "(" + s + ")"
+	}
+
+	if p.wrapping() {
+		s = p.softLine() + s
+		for range p.SubqueryIndent {
+			s = p.indent(s)
+		}
+
+		return p.group("(" + s + p.softLine() + ")")
+	}
+
+	s = strings.TrimRight(s, "\n")
+	for range p.SubqueryIndent {
+		s = p.padLines(s)
+	}
+
+	return "(\n" + s + "\n)"
+}
+
 func (p *printer) printBoolExpr(node *nodes.BoolExpr) string {
-	b := p.builder()
+	if node.Boolop == nodes.BoolExprType_NOT_EXPR {
+		return p.keyword("NOT ") + p.printRightOperand(node.Args[0], precNot)
+	}
 
-	for _, n := range node.Args {
-		nestedBool, ok := n.Node.(*nodes.Node_BoolExpr)
-		if ok && nestedBool.BoolExpr.Boolop != nodes.BoolExprType_NOT_EXPR {
-			b.append("(" + p.printNode(n) + ")")
-		} else {
-			b.append(p.printNode(n))
+	prec := precAnd
+	if node.Boolop == nodes.BoolExprType_OR_EXPR {
+		prec = precOr
+	}
+
+	args := node.Args
+	if p.ShortBetween {
+		args = p.foldBetween(node)
+
+		if len(args) == 1 {
+			return p.printNode(args[0])
 		}
 	}
 
+	b := p.builder()
+
+	for _, n := range args {
+		b.append(p.printLeftOperand(n, prec))
+	}
+
 	op := p.keyword("AND ")
 	if node.Boolop == nodes.BoolExprType_OR_EXPR {
 		op = p.keyword("OR ")
 	}
 
-	if p.Pretty {
+	if p.TrailingBoolOp {
+		op = " " + strings.TrimSpace(op)
+
+		switch {
+		case p.wrapping():
+			return p.group(b.join(op + p.line()))
+		case p.Pretty:
+			return b.join(op + "\n")
+		}
+
+		return b.join(op + " ")
+	}
+
+	switch {
+	case p.wrapping():
+		return p.group(b.join(p.line() + op))
+	case p.Pretty:
 		op = "\n" + op
-	} else {
+	default:
 		op = " " + op
 	}
 
@@ -1291,68 +2009,85 @@ func (p *printer) printBoolExpr(node *nodes.BoolExpr) string {
 
 func (p *printer) printUpdateTargets(list []*nodes.Node) string {
 	var (
-		multi *nodes.MultiAssignRef
+		rows  [][]string
 		names []string
 	)
 
-	b := p.builder()
+	for _, n := range list {
+		node, ok := n.Node.(*nodes.Node_ResTarget)
+		if !ok {
+			continue
+		}
 
-	for i := range list {
-		n := list[i]
+		target := p.identifier(node.ResTarget.Name) + p.printIndirection(node.ResTarget.Indirection)
 
-		node, ok := n.Node.(*nodes.Node_ResTarget)
-		if ok {
-			v, ok := node.ResTarget.Val.Node.(*nodes.Node_MultiAssignRef)
-			if ok {
-				multi = v.MultiAssignRef
+		multi, ok := node.ResTarget.Val.Node.(*nodes.Node_MultiAssignRef)
+		if !ok {
+			rows = append(rows, []string{target, "= " + p.printNode(node.ResTarget.Val)})
 
-				names = append(names, p.identifier(node.ResTarget.Name))
-			} else {
-				b.append(p.identifier(node.ResTarget.Name) + " = " + p.printNode(node.ResTarget.Val))
-			}
+			continue
+		}
+
+		// the columns assigned from one source are consecutive, the last one completes the assignment
+		names = append(names, target)
+		if multi.MultiAssignRef.Colno == multi.MultiAssignRef.Ncolumns {
+			rows = append(rows, []string{"(" + strings.Join(names, ", ") + ")", "= " + p.printMultiAssignRef(multi.MultiAssignRef)})
+			names = nil
 		}
 	}
 
-	if multi != nil {
-		b.append("(" + strings.Join(names, ", ") + ")" + " = " + p.printMultiAssignRef(multi))
+	if p.Pretty && p.AlignColumns {
+		return p.joinLines(alignRows(rows))
+	}
+
+	items := make([]string, len(rows))
+	for i, r := range rows {
+		items[i] = strings.Join(r, " ")
 	}
 
-	return b.join(", ")
+	return strings.Join(items, ", ")
 }
 
 func (p *printer) printUpdateStmt(node *nodes.UpdateStmt) string {
 	b := p.builder()
 	b.append(p.printWithClause(node.WithClause))
-	b.keyword("UPDATE")
-	b.append(p.printRangeVar(node.Relation))
-	b.LF()
-	b.keyword("SET")
-	b.LF()
-	b.appendPadded(p.printUpdateTargets(node.TargetList))
+	b.inlineClause(p.keyword("UPDATE"), p.printRangeVar(node.Relation))
+	b.clause(p.keyword("SET"), p.printUpdateTargets(node.TargetList))
 
 	w := p.printNode(node.WhereClause)
 	if w != "" {
-		b.keyword("WHERE")
-		b.LF()
-		b.appendPadded(w)
+		b.clause(p.keyword("WHERE"), w)
 	}
 
-	r := p.printNodes(node.ReturningList, ", ")
-	if r != "" {
-		b.keyword("RETURNING")
-		b.append(r)
-	}
+	p.printReturning(node.ReturningList, &b)
 
 	return b.join(" ")
 }
 
+// printReturning appends the RETURNING clause, which ends the statement.
+func (p *printer) printReturning(list []*nodes.Node, b *sqlBuilder) {
+	r := p.printNodes(list, ", ")
+	if r == "" {
+		return
+	}
+
+	if b.river() {
+		b.inlineClause(p.keyword("RETURNING"), r)
+
+		return
+	}
+
+	b.keyword("RETURNING")
+	b.append(r)
+}
+
 func (p *printer) printCreateTableAsStmt(node *nodes.CreateTableAsStmt) string {
 	b := p.builder()
 	b.keyword("CREATE")
 	b.append(p.relPersistence(node.Into.Rel))
 	b.keyword(ObjectTypeKeyword[node.Objtype])
 	b.keywordIf("IF NOT EXISTS", node.IfNotExists)
-	b.identifier(p.printIntoClause(node.Into))
+	b.append(p.printIntoClause(node.Into))
 	b.append(p.printSubClauseInlineSpace(node.Into.ColNames))
 	b.LF()
 
@@ -1378,7 +2113,7 @@ func (p *printer) printCreateTableAsStmt(node *nodes.CreateTableAsStmt) string {
 
 	if node.Into.TableSpaceName != "" {
 		b.keyword("TABLESPACE")
-		b.append(node.Into.TableSpaceName)
+		b.identifier(node.Into.TableSpaceName)
 		b.LF()
 	}
 
@@ -1436,7 +2171,8 @@ func (p *printer) printCreateExtensionStmt(node *nodes.CreateExtensionStmt) stri
 
 func (p *printer) printRangeSubselect(node *nodes.RangeSubselect) string {
 	b := p.builder()
-	b.append("(" + p.printNode(node.Subquery) + ")")
+	b.keywordIf("LATERAL", node.Lateral)
+	b.append(p.printSubquery(node.Subquery))
 
 	a := p.printAlias(node.Alias)
 	if a != "" {
@@ -1457,11 +2193,16 @@ func (p *printer) printTruncateStmt(node *nodes.TruncateStmt) string {
 }
 
 func (p *printer) printMultiAssignRef(node *nodes.MultiAssignRef) string {
-	return "(" + p.printNode(node.Source) + ")"
+	return p.printNode(node.Source)
 }
 
 func (p *printer) printRowExpr(node *nodes.RowExpr) string {
-	return p.printNodes(node.Args, ", ")
+	args := p.wrapList("(", ")", p.printArr(node.Args))
+	if node.RowFormat == nodes.CoercionForm_COERCE_EXPLICIT_CALL {
+		return p.keyword("ROW") + args
+	}
+
+	return args
 }
 
 func (p *printer) printExplainStmt(node *nodes.ExplainStmt) string {
@@ -1476,8 +2217,13 @@ func (p *printer) printExplainStmt(node *nodes.ExplainStmt) string {
 
 func (p *printer) printNullTest(node *nodes.NullTest) string {
 	b := p.builder()
-	b.append(p.printNode(node.Xpr), p.printNode(node.Arg))
-	b.keywordIfElse("IS NULL", "IS NOT NULL", node.Nulltesttype == nodes.NullTestType_IS_NULL)
+	b.append(p.printLeftOperand(node.Arg, precIs))
+
+	if p.ShortNullTest {
+		b.keywordIfElse("ISNULL", "NOTNULL", node.Nulltesttype == nodes.NullTestType_IS_NULL)
+	} else {
+		b.keywordIfElse("IS NULL", "IS NOT NULL", node.Nulltesttype == nodes.NullTestType_IS_NULL)
+	}
 
 	return b.join(" ")
 }
@@ -1517,15 +2263,62 @@ func (p *printer) printCurrentOfExpr(node *nodes.CurrentOfExpr) string {
 
 func (p *printer) printRangeFunction(node *nodes.RangeFunction) string {
 	b := p.builder()
-	b.append(p.printNodes(node.Functions, ", "))
+	b.keywordIf("LATERAL", node.Lateral)
+
+	if node.IsRowsfrom {
+		items := make([]string, 0, len(node.Functions))
+		for _, f := range node.Functions {
+			items = append(items, p.printRangeFunctionItem(f))
+		}
+
+		b.keyword("ROWS FROM")
+		b.append("(" + strings.Join(items, ", ") + ")")
+	} else if len(node.Functions) > 0 {
+		b.append(p.printRangeFunctionItem(node.Functions[0]))
+	}
+
 	b.keywordIf("WITH ORDINALITY", node.Ordinality)
 
-	if node.Alias != nil {
+	if node.Alias != nil || len(node.Coldeflist) > 0 {
 		b.keyword("AS")
 		b.append(p.printAlias(node.Alias))
+		b.append(p.printSubClauseInlineSpace(node.Coldeflist))
+	}
+
+	return b.join(" ")
+}
+
+// printRangeFunctionItem prints one entry of RangeFunction.Functions, a list holding the function
+// call and its optional column definition list.
+func (p *printer) printRangeFunctionItem(node *nodes.Node) string {
+	list := node.GetList()
+	if list == nil || len(list.Items) == 0 {
+		return p.printNode(node)
+	}
+
+	b := p.builder()
+	b.append(p.printNode(list.Items[0]))
+
+	if len(list.Items) > 1 {
+		if coldefs := list.Items[1].GetList(); coldefs != nil && len(coldefs.Items) > 0 {
+			b.keyword("AS")
+			b.append(p.printSubClauseInlineSpace(coldefs.Items))
+		}
 	}
 
-	b.append(p.printSubClauseInlineSpace(node.Coldeflist))
+	return b.join(" ")
+}
+
+func (p *printer) printRangeTableSample(node *nodes.RangeTableSample) string {
+	b := p.builder()
+	b.append(p.printNode(node.Relation))
+	b.keyword("TABLESAMPLE")
+	b.append(p.identifier(extractStrings(node.Method)...) + p.printSubClauseInline(node.Args))
+
+	if node.Repeatable != nil {
+		b.keyword("REPEATABLE")
+		b.append("(" + p.printNode(node.Repeatable) + ")")
+	}
 
 	return b.join(" ")
 }
@@ -1583,7 +2376,7 @@ func (p *printer) printCreateOpClassStmt(node *nodes.CreateOpClassStmt) string {
 	b.keyword("FOR TYPE")
 	b.append(p.printTypeName(node.Datatype))
 	b.keyword("USING")
-	b.append(node.Amname)
+	b.identifier(node.Amname)
 	b.keyword("AS")
 	b.append(p.printCSV(node.Items))
 
@@ -1606,58 +2399,148 @@ func (p *printer) printCreateOpClassItem(node *nodes.CreateOpClassItem) string {
 	return b.join(" ")
 }
 
+// printWindowDef prints an entry of the WINDOW clause, or an anonymous specification.
 func (p *printer) printWindowDef(node *nodes.WindowDef) string {
-	b := p.builder()
+	spec := p.printWindowSpec(node.Refname, node.PartitionClause, node.OrderClause,
+		FrameOption(node.FrameOptions), node.StartOffset, node.EndOffset)
 
-	if node.Name != "" {
-		b.identifier(node.Name)
+	if node.Name == "" {
+		return spec
 	}
 
-	if len(node.PartitionClause) > 0 {
-		if node.Name != "" {
-			b.keyword("AS")
-		}
-
-		b.keyword("(PARTITION BY")
-		b.append(p.printNodes(node.PartitionClause, ", "))
-
-		if len(node.OrderClause) > 0 {
-			b.keyword("ORDER BY")
-			b.append(p.printNodes(node.OrderClause, ", "))
-		}
+	return p.identifier(node.Name) + p.keyword(" AS ") + spec
+}
 
-		b.addToLast(")")
-	} else if node.Name == "" {
-		b.append("()")
+// printOver prints the target of an OVER clause, either a window name or a specification.
+func (p *printer) printOver(node *nodes.WindowDef) string {
+	if node.Name != "" {
+		return p.identifier(node.Name)
 	}
 
-	return b.join(" ")
+	return p.printWindowDef(node)
 }
 
-func (p *printer) printRoleSpec(node *nodes.RoleSpec) string {
-	return node.Rolename
+func (p *printer) printWindowClause(node *nodes.WindowClause) string {
+	spec := p.printWindowSpec(node.Refname, node.PartitionClause, node.OrderClause,
+		FrameOption(node.FrameOptions), node.StartOffset, node.EndOffset)
+
+	return p.identifier(node.Name) + p.keyword(" AS ") + spec
 }
 
-func (p *printer) printRuleStmt(node *nodes.RuleStmt) string {
-	b := p.builder()
-	b.keyword("CREATE RULE")
-	b.identifier(node.Rulename)
-	b.keyword("AS")
-	b.keyword("ON")
-	b.keyword(CmdTypeKeyword[node.Event])
-	b.keyword("TO")
-	b.identifier(p.printRangeVar(node.Relation))
+func (p *printer) printWindowSpec(refname string, partition, order []*nodes.Node, frame FrameOption, start, end *nodes.Node) string {
+	var parts []string
 
-	if node.Instead {
-		b.keyword("DO INSTEAD")
-		b.append(p.printNodes(node.Actions, ", "))
-	} else {
-		b.keyword("DO ALSO NOTIFY")
-		b.append(p.printNodes(node.Actions, ", "))
+	if refname != "" {
+		parts = append(parts, p.identifier(refname))
 	}
 
-	return b.join(" ")
-}
+	if len(partition) > 0 {
+		parts = append(parts, p.keyword("PARTITION BY ")+p.printNodes(partition, ", "))
+	}
+
+	if len(order) > 0 {
+		parts = append(parts, p.keyword("ORDER BY ")+p.printNodes(order, ", "))
+	}
+
+	if f := p.printFrame(frame, start, end); f != "" {
+		parts = append(parts, f)
+	}
+
+	return p.wrapParens("(", ")", strings.Join(parts, p.line()))
+}
+
+func (p *printer) printFrame(frame FrameOption, start, end *nodes.Node) string {
+	if frame&FrameNonDefault == 0 {
+		return ""
+	}
+
+	b := p.builder()
+
+	for _, mode := range []FrameOption{FrameRange, FrameRows, FrameGroups} {
+		if frame&mode != 0 {
+			b.keyword(FrameModeKeyword[mode])
+		}
+	}
+
+	startBound := p.printFrameBound(frame, start, FrameStartUnboundedPreceding, FrameStartUnboundedFollowing,
+		FrameStartCurrentRow, FrameStartOffsetPreceding, FrameStartOffsetFollowing)
+
+	if frame&FrameBetween != 0 {
+		b.keyword("BETWEEN")
+		b.append(startBound)
+		b.keyword("AND")
+		b.append(p.printFrameBound(frame, end, FrameEndUnboundedPreceding, FrameEndUnboundedFollowing,
+			FrameEndCurrentRow, FrameEndOffsetPreceding, FrameEndOffsetFollowing))
+	} else {
+		b.append(startBound)
+	}
+
+	for _, exclude := range []FrameOption{FrameExcludeCurrentRow, FrameExcludeGroup, FrameExcludeTies} {
+		if frame&exclude != 0 {
+			b.keyword(FrameExcludeKeyword[exclude])
+		}
+	}
+
+	return b.join(" ")
+}
+
+func (p *printer) printFrameBound(frame FrameOption, offset *nodes.Node,
+	unboundedPreceding, unboundedFollowing, currentRow, offsetPreceding, offsetFollowing FrameOption,
+) string {
+	switch {
+	case frame&unboundedPreceding != 0:
+		return p.keyword("UNBOUNDED PRECEDING")
+	case frame&unboundedFollowing != 0:
+		return p.keyword("UNBOUNDED FOLLOWING")
+	case frame&currentRow != 0:
+		return p.keyword("CURRENT ROW")
+	case frame&offsetPreceding != 0:
+		return p.printNode(offset) + p.keyword(" PRECEDING")
+	case frame&offsetFollowing != 0:
+		return p.printNode(offset) + p.keyword(" FOLLOWING")
+	}
+
+	return ""
+}
+
+// printWindowFunc reports an error, the planner node only references the function by oid.
+func (p *printer) printWindowFunc(_ *nodes.WindowFunc) string {
+	p.addError(ErrPrinter.Wrap("WindowFunc references its function by oid and cannot be printed"))
+
+	return ""
+}
+
+// printWindowFuncRunCondition reports an error, the planner node only references the operator by oid.
+func (p *printer) printWindowFuncRunCondition(_ *nodes.WindowFuncRunCondition) string {
+	p.addError(ErrPrinter.Wrap("WindowFuncRunCondition references its operator by oid and cannot be printed"))
+
+	return ""
+}
+
+func (p *printer) printRoleSpec(node *nodes.RoleSpec) string {
+	return node.Rolename
+}
+
+func (p *printer) printRuleStmt(node *nodes.RuleStmt) string {
+	b := p.builder()
+	b.keyword("CREATE RULE")
+	b.identifier(node.Rulename)
+	b.keyword("AS")
+	b.keyword("ON")
+	b.keyword(CmdTypeKeyword[node.Event])
+	b.keyword("TO")
+	b.append(p.printRangeVar(node.Relation))
+
+	if node.Instead {
+		b.keyword("DO INSTEAD")
+		b.append(p.printNodes(node.Actions, ", "))
+	} else {
+		b.keyword("DO ALSO NOTIFY")
+		b.append(p.printNodes(node.Actions, ", "))
+	}
+
+	return b.join(" ")
+}
 
 func (p *printer) printNotifyStmt(node *nodes.NotifyStmt) string {
 	return node.Conditionname
@@ -1666,7 +2549,7 @@ func (p *printer) printNotifyStmt(node *nodes.NotifyStmt) string {
 func (p *printer) printCreateTransformStmt(node *nodes.CreateTransformStmt) string {
 	b := p.builder()
 	b.keyword("CREATE TRANSFORM FOR")
-	b.identifier(p.printTypeName(node.TypeName))
+	b.append(p.printTypeName(node.TypeName))
 	b.keyword("LANGUAGE")
 	b.identifier(node.Lang)
 	b.append("(")
@@ -1685,3 +2568,479 @@ func (p *printer) printCreateTransformStmt(node *nodes.CreateTransformStmt) stri
 func (p *printer) printBoolean(node *nodes.Boolean) string {
 	return strconv.FormatBool(node.Boolval)
 }
+
+func (p *printer) printJsonFormat(node *nodes.JsonFormat) string {
+	if node == nil || node.FormatType != nodes.JsonFormatType_JS_FORMAT_JSON {
+		return ""
+	}
+
+	b := p.builder()
+	b.keyword("FORMAT JSON")
+
+	if e := JsonEncodingKeyword[node.Encoding]; e != "" {
+		b.keyword("ENCODING")
+		b.keyword(e)
+	}
+
+	return b.join(" ")
+}
+
+func (p *printer) printJsonReturning(node *nodes.JsonReturning) string {
+	if node == nil {
+		return ""
+	}
+
+	return p.printJsonFormat(node.Format)
+}
+
+func (p *printer) printJsonOutput(node *nodes.JsonOutput) string {
+	if node == nil || node.TypeName == nil {
+		return ""
+	}
+
+	b := p.builder()
+	b.keyword("RETURNING")
+	b.append(p.printTypeName(node.TypeName))
+	b.append(p.printJsonReturning(node.Returning))
+
+	return b.join(" ")
+}
+
+func (p *printer) printJsonValueExpr(node *nodes.JsonValueExpr) string {
+	b := p.builder()
+	b.append(p.printNode(node.RawExpr))
+	b.append(p.printJsonFormat(node.Format))
+
+	return b.join(" ")
+}
+
+func (p *printer) printJsonKeyValue(node *nodes.JsonKeyValue) string {
+	b := p.builder()
+	b.append(p.printNode(node.Key))
+	b.keyword("VALUE")
+	b.append(p.printJsonValueExpr(node.Value))
+
+	return b.join(" ")
+}
+
+// printJsonOnNull renders the ON NULL clause only when it differs from the constructor's default.
+func (p *printer) printJsonOnNull(b *sqlBuilder, absentOnNull, defaultAbsent bool) {
+	if absentOnNull != defaultAbsent {
+		b.keywordIfElse("ABSENT ON NULL", "NULL ON NULL", absentOnNull)
+	}
+}
+
+func (p *printer) printJsonObjectConstructor(node *nodes.JsonObjectConstructor) string {
+	b := p.builder()
+	b.append(p.printNodes(node.Exprs, ", "))
+	p.printJsonOnNull(&b, node.AbsentOnNull, false)
+	b.keywordIf("WITH UNIQUE KEYS", node.Unique)
+	b.append(p.printJsonOutput(node.Output))
+
+	return p.keyword("JSON_OBJECT") + "(" + b.join(" ") + ")"
+}
+
+func (p *printer) printJsonArrayConstructor(node *nodes.JsonArrayConstructor) string {
+	b := p.builder()
+	b.append(p.printNodes(node.Exprs, ", "))
+
+	if len(node.Exprs) > 0 {
+		p.printJsonOnNull(&b, node.AbsentOnNull, true)
+	}
+
+	b.append(p.printJsonOutput(node.Output))
+
+	return p.keyword("JSON_ARRAY") + "(" + b.join(" ") + ")"
+}
+
+func (p *printer) printJsonArrayQueryConstructor(node *nodes.JsonArrayQueryConstructor) string {
+	b := p.builder()
+	b.append(p.printNode(node.Query))
+	b.append(p.printJsonFormat(node.Format))
+	b.append(p.printJsonOutput(node.Output))
+
+	return p.keyword("JSON_ARRAY") + "(" + b.join(" ") + ")"
+}
+
+// printJsonAggConstructor renders the clauses that follow the closing parenthesis of a JSON aggregate.
+func (p *printer) printJsonAggConstructor(node *nodes.JsonAggConstructor) string {
+	if node == nil {
+		return ""
+	}
+
+	b := p.builder()
+	p.printAggSuffix(&b, node.AggFilter, node.Over)
+
+	return b.join(" ")
+}
+
+func (p *printer) printJsonObjectAgg(node *nodes.JsonObjectAgg) string {
+	b := p.builder()
+	b.append(p.printJsonKeyValue(node.Arg))
+	p.printJsonOnNull(&b, node.AbsentOnNull, false)
+	b.keywordIf("WITH UNIQUE KEYS", node.Unique)
+
+	if node.Constructor != nil {
+		b.append(p.printJsonOutput(node.Constructor.Output))
+	}
+
+	result := p.keyword("JSON_OBJECTAGG") + "(" + b.join(" ") + ")"
+
+	if suffix := p.printJsonAggConstructor(node.Constructor); suffix != "" {
+		result += " " + suffix
+	}
+
+	return result
+}
+
+func (p *printer) printJsonArrayAgg(node *nodes.JsonArrayAgg) string {
+	b := p.builder()
+	b.append(p.printJsonValueExpr(node.Arg))
+
+	if node.Constructor != nil && len(node.Constructor.AggOrder) > 0 {
+		b.keyword("ORDER BY")
+		b.append(p.printNodes(node.Constructor.AggOrder, ", "))
+	}
+
+	p.printJsonOnNull(&b, node.AbsentOnNull, true)
+
+	if node.Constructor != nil {
+		b.append(p.printJsonOutput(node.Constructor.Output))
+	}
+
+	result := p.keyword("JSON_ARRAYAGG") + "(" + b.join(" ") + ")"
+
+	if suffix := p.printJsonAggConstructor(node.Constructor); suffix != "" {
+		result += " " + suffix
+	}
+
+	return result
+}
+
+func (p *printer) printJsonIsPredicate(node *nodes.JsonIsPredicate) string {
+	b := p.builder()
+	b.append(p.printLeftOperand(node.Expr, precIs))
+	b.append(p.printJsonFormat(node.Format))
+	b.keyword("IS JSON")
+	b.keyword(JsonValueTypeKeyword[node.ItemType])
+	b.keywordIf("WITH UNIQUE KEYS", node.UniqueKeys)
+
+	return b.join(" ")
+}
+
+func (p *printer) printJsonParseExpr(node *nodes.JsonParseExpr) string {
+	b := p.builder()
+	b.append(p.printJsonValueExpr(node.Expr))
+	b.keywordIf("WITH UNIQUE KEYS", node.UniqueKeys)
+	b.append(p.printJsonOutput(node.Output))
+
+	return p.keyword("JSON") + "(" + b.join(" ") + ")"
+}
+
+func (p *printer) printJsonScalarExpr(node *nodes.JsonScalarExpr) string {
+	b := p.builder()
+	b.append(p.printNode(node.Expr))
+	b.append(p.printJsonOutput(node.Output))
+
+	return p.keyword("JSON_SCALAR") + "(" + b.join(" ") + ")"
+}
+
+func (p *printer) printJsonSerializeExpr(node *nodes.JsonSerializeExpr) string {
+	b := p.builder()
+	b.append(p.printJsonValueExpr(node.Expr))
+	b.append(p.printJsonOutput(node.Output))
+
+	return p.keyword("JSON_SERIALIZE") + "(" + b.join(" ") + ")"
+}
+
+func (p *printer) printXmlExpr(node *nodes.XmlExpr) string {
+	switch node.Op {
+	case nodes.XmlExprOp_IS_DOCUMENT:
+		return p.printLeftOperand(node.Args[0], precIs) + p.keyword(" IS DOCUMENT")
+	case nodes.XmlExprOp_IS_XMLPARSE:
+		return p.printXmlParse(node)
+	case nodes.XmlExprOp_IS_XMLROOT:
+		return p.printXmlRoot(node)
+	}
+
+	b := p.builder()
+
+	if node.Name != "" {
+		b.append(p.keyword("NAME ") + p.identifier(node.Name))
+	}
+
+	switch node.Op {
+	case nodes.XmlExprOp_IS_XMLELEMENT:
+		if len(node.NamedArgs) > 0 {
+			b.append(p.keyword("XMLATTRIBUTES") + "(" + p.printNodes(node.NamedArgs, ", ") + ")")
+		}
+	case nodes.XmlExprOp_IS_XMLFOREST:
+		b.append(p.printNodes(node.NamedArgs, ", "))
+	}
+
+	b.append(p.printArr(node.Args)...)
+
+	return p.keyword(XmlExprOpKeyword[node.Op]) + "(" + b.join(", ") + ")"
+}
+
+func (p *printer) printXmlParse(node *nodes.XmlExpr) string {
+	b := p.builder()
+	b.keyword(XmlOptionTypeKeyword[node.Xmloption])
+	b.append(p.printNode(node.Args[0]))
+
+	if len(node.Args) > 1 && isBoolConst(node.Args[1], true) {
+		b.keyword("PRESERVE WHITESPACE")
+	}
+
+	return p.keyword("XMLPARSE") + "(" + b.join(" ") + ")"
+}
+
+func (p *printer) printXmlRoot(node *nodes.XmlExpr) string {
+	b := p.builder()
+	b.append(p.printNode(node.Args[0]))
+
+	version := p.keyword("VERSION NO VALUE")
+	if c, ok := node.Args[1].Node.(*nodes.Node_AConst); !ok || !c.AConst.Isnull {
+		version = p.keyword("VERSION ") + p.printNode(node.Args[1])
+	}
+
+	b.append(version)
+
+	if s := XmlStandaloneKeyword[XmlStandalone(getInt32(node.Args[2]))]; s != "" {
+		b.append(p.keyword(s))
+	}
+
+	return p.keyword("XMLROOT") + "(" + b.join(", ") + ")"
+}
+
+func (p *printer) printXmlSerialize(node *nodes.XmlSerialize) string {
+	b := p.builder()
+	b.keyword(XmlOptionTypeKeyword[node.Xmloption])
+	b.append(p.printNode(node.Expr))
+	b.keyword("AS")
+	b.append(p.printTypeName(node.TypeName))
+	b.keywordIf("INDENT", node.Indent)
+
+	return p.keyword("XMLSERIALIZE") + "(" + b.join(" ") + ")"
+}
+
+func (p *printer) printXmlNamespace(node *nodes.Node) string {
+	r, ok := node.Node.(*nodes.Node_ResTarget)
+	if ok && r.ResTarget.Name == "" {
+		return p.keyword("DEFAULT ") + p.printNode(r.ResTarget.Val)
+	}
+
+	return p.printNode(node)
+}
+
+func (p *printer) printRangeTableFunc(node *nodes.RangeTableFunc) string {
+	b := p.builder()
+
+	if len(node.Namespaces) > 0 {
+		ns := make([]string, len(node.Namespaces))
+		for i, n := range node.Namespaces {
+			ns[i] = p.printXmlNamespace(n)
+		}
+
+		b.append(p.keyword("XMLNAMESPACES") + "(" + strings.Join(ns, ", ") + "),")
+		b.LF()
+	}
+
+	b.append(p.printNode(node.Rowexpr))
+	b.keyword("PASSING")
+	b.append(p.printNode(node.Docexpr))
+	b.LF()
+	b.keyword("COLUMNS")
+	b.append(p.printCSV(node.Columns))
+
+	body := b.join(" ")
+	if p.Pretty {
+		body = "\n" + p.padLines(strings.TrimRight(body, "\n")) + "\n"
+	}
+
+	result := p.builder()
+	result.keywordIf("LATERAL", node.Lateral)
+	result.append(p.keyword("XMLTABLE") + "(" + body + ")")
+
+	if node.Alias != nil {
+		result.keyword("AS")
+		result.append(p.printAlias(node.Alias))
+	}
+
+	return result.join(" ")
+}
+
+func (p *printer) printRangeTableFuncCol(node *nodes.RangeTableFuncCol) string {
+	b := p.builder()
+	b.identifier(node.Colname)
+
+	if node.ForOrdinality {
+		b.keyword("FOR ORDINALITY")
+
+		return b.join(" ")
+	}
+
+	b.append(p.printTypeName(node.TypeName))
+
+	if node.Colexpr != nil {
+		b.keyword("PATH")
+		b.append(p.printNode(node.Colexpr))
+	}
+
+	if node.Coldefexpr != nil {
+		b.keyword("DEFAULT")
+		b.append(p.printNode(node.Coldefexpr))
+	}
+
+	b.keywordIf("NOT NULL", node.IsNotNull)
+
+	return b.join(" ")
+}
+
+func (p *printer) printGroupingSet(node *nodes.GroupingSet) string {
+	if node.Kind == nodes.GroupingSetKind_GROUPING_SET_EMPTY {
+		return "()"
+	}
+
+	items := p.printArr(node.Content)
+
+	content := strings.Join(items, ", ")
+	if node.Kind == nodes.GroupingSetKind_GROUPING_SET_SIMPLE {
+		return content
+	}
+
+	if p.wrapping() {
+		return p.keyword(GroupingSetKindKeyword[node.Kind]) + " " + p.wrapList("(", ")", items)
+	}
+
+	// a SimpleLen of 0 keeps the list on one line
+	if p.Pretty && p.SimpleLen > 0 && len(content) > p.SimpleLen {
+		content = "\n" + p.padLines(strings.Join(items, ",\n")) + "\n"
+	}
+
+	return p.keyword(GroupingSetKindKeyword[node.Kind]) + " (" + content + ")"
+}
+
+func (p *printer) printGroupingFunc(node *nodes.GroupingFunc) string {
+	return p.keyword("GROUPING") + p.wrapList("(", ")", p.printArr(node.Args))
+}
+
+func (p *printer) printAIndirection(node *nodes.A_Indirection) string {
+	return p.printIndirectionArg(node) + p.printIndirection(node.Indirection)
+}
+
+// printIndirectionArg wraps the base expression in parentheses unless it is a column or param
+// reference directly followed by a subscript (`arr[1]`), the only forms the grammar accepts bare.
+func (p *printer) printIndirectionArg(node *nodes.A_Indirection) string {
+	arg := p.printNode(node.Arg)
+
+	if len(node.Indirection) > 0 {
+		if _, ok := node.Indirection[0].Node.(*nodes.Node_AIndices); ok {
+			switch node.Arg.Node.(type) {
+			case *nodes.Node_ColumnRef, *nodes.Node_ParamRef:
+				return arg
+			}
+		}
+	}
+
+	return "(" + arg + ")"
+}
+
+func (p *printer) printIndirection(list []*nodes.Node) string {
+	var b strings.Builder
+
+	for _, n := range list {
+		switch i := n.Node.(type) {
+		case *nodes.Node_AIndices:
+			b.WriteString(p.printAIndices(i.AIndices))
+		case *nodes.Node_AStar:
+			b.WriteString(".*")
+		default:
+			b.WriteString("." + p.printNode(n))
+		}
+	}
+
+	return b.String()
+}
+
+func (p *printer) printAIndices(node *nodes.A_Indices) string {
+	if node.IsSlice {
+		return "[" + p.printNode(node.Lidx) + ":" + p.printNode(node.Uidx) + "]"
+	}
+
+	return "[" + p.printNode(node.Uidx) + "]"
+}
+
+func (p *printer) printCollateClause(node *nodes.CollateClause) string {
+	return p.printLeftOperand(node.Arg, precCollate) + p.keyword(" COLLATE ") + p.printCollationName(node.Collname)
+}
+
+func (p *printer) printCollationName(list []*nodes.Node) string {
+	return p.identifier(extractStrings(list)...)
+}
+
+func (p *printer) printMinMaxExpr(node *nodes.MinMaxExpr) string {
+	return p.keyword(MinMaxOpKeyword[node.Op]) + p.wrapList("(", ")", p.printArr(node.Args))
+}
+
+func (p *printer) printNullIfExpr(node *nodes.NullIfExpr) string {
+	return p.keyword("NULLIF") + "(" + p.printNodes(node.Args, ", ") + ")"
+}
+
+func (p *printer) printBooleanTest(node *nodes.BooleanTest) string {
+	b := p.builder()
+	b.append(p.printLeftOperand(node.Arg, precIs))
+	b.keyword(BoolTestTypeKeyword[node.Booltesttype])
+
+	return b.join(" ")
+}
+
+// printCaseTestExpr renders nothing, the placeholder stands in for the CASE operand which is
+// printed by the enclosing CaseExpr.
+func (p *printer) printCaseTestExpr(_ *nodes.CaseTestExpr) string {
+	return ""
+}
+
+func (p *printer) printRowCompareExpr(node *nodes.RowCompareExpr) string {
+	b := p.builder()
+	b.append("(" + p.printNodes(node.Largs, ", ") + ")")
+	b.append(RowCompareTypeOperator[node.Rctype])
+	b.append("(" + p.printNodes(node.Rargs, ", ") + ")")
+
+	return b.join(" ")
+}
+
+func (p *printer) printTableLikeClause(node *nodes.TableLikeClause) string {
+	b := p.builder()
+	b.keyword("LIKE")
+	b.append(p.printRangeVar(node.Relation))
+
+	options := TableLikeOption(node.Options)
+
+	var named TableLikeOption
+	for _, o := range TableLikeOptions {
+		named |= o
+	}
+
+	// INCLUDING ALL also sets the bits above the named flags, so any of those means the options
+	// were written as INCLUDING ALL followed by EXCLUDING clauses.
+	if options&^named != 0 {
+		b.keyword("INCLUDING ALL")
+
+		for _, o := range TableLikeOptions {
+			if options&o == 0 {
+				b.keyword("EXCLUDING " + TableLikeOptionKeyword[o])
+			}
+		}
+
+		return b.join(" ")
+	}
+
+	for _, o := range TableLikeOptions {
+		if options&o != 0 {
+			b.keyword("INCLUDING " + TableLikeOptionKeyword[o])
+		}
+	}
+
+	return b.join(" ")
+}
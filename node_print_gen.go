@@ -22,7 +22,12 @@ func (p *printer) printNode(node *nodes.Node) (result string) {
 		return
 	}
 	p.level += 1
+	p.stack = append(p.stack, node)
+	errs := len(p.errs)
 	defer func() {
+		if p.bestEffort && len(p.errs) > errs {
+			result = p.passThrough(node, errs, result)
+		}
 		if p.debug {
 			pad := p.pad(p.level - 1)
 			t := reflect.TypeOf(node.Node)
@@ -34,6 +39,7 @@ func (p *printer) printNode(node *nodes.Node) (result string) {
 			p.debugOutput = append([]string{debugLine}, p.debugOutput...)
 		}
 		p.level -= 1
+		p.stack = p.stack[:len(p.stack)-1]
 	}()
 
 	switch n := node.Node.(type) {
@@ -852,966 +858,786 @@ func (p *printer) printNode(node *nodes.Node) (result string) {
 }
 
 func (p *printer) printOidList(node *nodes.OidList) string {
-	p.addError(errors.New("OidList not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printIntList(node *nodes.IntList) string {
-	p.addError(errors.New("IntList not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printTableFunc(node *nodes.TableFunc) string {
-	p.addError(errors.New("TableFunc not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printVar(node *nodes.Var) string {
-	p.addError(errors.New("Var not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printParam(node *nodes.Param) string {
-	p.addError(errors.New("Param not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAggref(node *nodes.Aggref) string {
-	p.addError(errors.New("Aggref not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printGroupingFunc(node *nodes.GroupingFunc) string {
-	p.addError(errors.New("GroupingFunc not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printWindowFunc(node *nodes.WindowFunc) string {
-	p.addError(errors.New("WindowFunc not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printWindowFuncRunCondition(node *nodes.WindowFuncRunCondition) string {
-	p.addError(errors.New("WindowFuncRunCondition not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printMergeSupportFunc(node *nodes.MergeSupportFunc) string {
-	p.addError(errors.New("MergeSupportFunc not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printSubscriptingRef(node *nodes.SubscriptingRef) string {
-	p.addError(errors.New("SubscriptingRef not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printFuncExpr(node *nodes.FuncExpr) string {
-	p.addError(errors.New("FuncExpr not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printOpExpr(node *nodes.OpExpr) string {
-	p.addError(errors.New("OpExpr not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printDistinctExpr(node *nodes.DistinctExpr) string {
-	p.addError(errors.New("DistinctExpr not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printNullIfExpr(node *nodes.NullIfExpr) string {
-	p.addError(errors.New("NullIfExpr not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printScalarArrayOpExpr(node *nodes.ScalarArrayOpExpr) string {
-	p.addError(errors.New("ScalarArrayOpExpr not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printSubPlan(node *nodes.SubPlan) string {
-	p.addError(errors.New("SubPlan not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlternativeSubPlan(node *nodes.AlternativeSubPlan) string {
-	p.addError(errors.New("AlternativeSubPlan not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printFieldSelect(node *nodes.FieldSelect) string {
-	p.addError(errors.New("FieldSelect not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printFieldStore(node *nodes.FieldStore) string {
-	p.addError(errors.New("FieldStore not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printRelabelType(node *nodes.RelabelType) string {
-	p.addError(errors.New("RelabelType not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCoerceViaIo(node *nodes.CoerceViaIO) string {
-	p.addError(errors.New("CoerceViaIO not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printArrayCoerceExpr(node *nodes.ArrayCoerceExpr) string {
-	p.addError(errors.New("ArrayCoerceExpr not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printConvertRowtypeExpr(node *nodes.ConvertRowtypeExpr) string {
-	p.addError(errors.New("ConvertRowtypeExpr not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCollateExpr(node *nodes.CollateExpr) string {
-	p.addError(errors.New("CollateExpr not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printCaseTestExpr(node *nodes.CaseTestExpr) string {
-	p.addError(errors.New("CaseTestExpr not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printArrayExpr(node *nodes.ArrayExpr) string {
-	p.addError(errors.New("ArrayExpr not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printRowCompareExpr(node *nodes.RowCompareExpr) string {
-	p.addError(errors.New("RowCompareExpr not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printMinMaxExpr(node *nodes.MinMaxExpr) string {
-	p.addError(errors.New("MinMaxExpr not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printXmlExpr(node *nodes.XmlExpr) string {
-	p.addError(errors.New("XmlExpr not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printJsonFormat(node *nodes.JsonFormat) string {
-	p.addError(errors.New("JsonFormat not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printJsonReturning(node *nodes.JsonReturning) string {
-	p.addError(errors.New("JsonReturning not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printJsonValueExpr(node *nodes.JsonValueExpr) string {
-	p.addError(errors.New("JsonValueExpr not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printJsonConstructorExpr(node *nodes.JsonConstructorExpr) string {
-	p.addError(errors.New("JsonConstructorExpr not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printJsonIsPredicate(node *nodes.JsonIsPredicate) string {
-	p.addError(errors.New("JsonIsPredicate not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printJsonBehavior(node *nodes.JsonBehavior) string {
-	p.addError(errors.New("JsonBehavior not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printJsonExpr(node *nodes.JsonExpr) string {
-	p.addError(errors.New("JsonExpr not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printJsonTablePath(node *nodes.JsonTablePath) string {
-	p.addError(errors.New("JsonTablePath not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printJsonTablePathScan(node *nodes.JsonTablePathScan) string {
-	p.addError(errors.New("JsonTablePathScan not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printJsonTableSiblingJoin(node *nodes.JsonTableSiblingJoin) string {
-	p.addError(errors.New("JsonTableSiblingJoin not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printBooleanTest(node *nodes.BooleanTest) string {
-	p.addError(errors.New("BooleanTest not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printMergeAction(node *nodes.MergeAction) string {
-	p.addError(errors.New("MergeAction not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCoerceToDomain(node *nodes.CoerceToDomain) string {
-	p.addError(errors.New("CoerceToDomain not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCoerceToDomainValue(node *nodes.CoerceToDomainValue) string {
-	p.addError(errors.New("CoerceToDomainValue not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printNextValueExpr(node *nodes.NextValueExpr) string {
-	p.addError(errors.New("NextValueExpr not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printInferenceElem(node *nodes.InferenceElem) string {
-	p.addError(errors.New("InferenceElem not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printTargetEntry(node *nodes.TargetEntry) string {
-	p.addError(errors.New("TargetEntry not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printRangeTblRef(node *nodes.RangeTblRef) string {
-	p.addError(errors.New("RangeTblRef not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printFromExpr(node *nodes.FromExpr) string {
-	p.addError(errors.New("FromExpr not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printOnConflictExpr(node *nodes.OnConflictExpr) string {
-	p.addError(errors.New("OnConflictExpr not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printQuery(node *nodes.Query) string {
-	p.addError(errors.New("Query not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printCollateClause(node *nodes.CollateClause) string {
-	p.addError(errors.New("CollateClause not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printAIndices(node *nodes.A_Indices) string {
-	p.addError(errors.New("A_Indices not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printAIndirection(node *nodes.A_Indirection) string {
-	p.addError(errors.New("A_Indirection not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printRangeTableFunc(node *nodes.RangeTableFunc) string {
-	p.addError(errors.New("RangeTableFunc not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printRangeTableFuncCol(node *nodes.RangeTableFuncCol) string {
-	p.addError(errors.New("RangeTableFuncCol not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printRangeTableSample(node *nodes.RangeTableSample) string {
-	p.addError(errors.New("RangeTableSample not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printTableLikeClause(node *nodes.TableLikeClause) string {
-	p.addError(errors.New("TableLikeClause not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printXmlSerialize(node *nodes.XmlSerialize) string {
-	p.addError(errors.New("XmlSerialize not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printPartitionElem(node *nodes.PartitionElem) string {
-	p.addError(errors.New("PartitionElem not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printPartitionSpec(node *nodes.PartitionSpec) string {
-	p.addError(errors.New("PartitionSpec not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printPartitionBoundSpec(node *nodes.PartitionBoundSpec) string {
-	p.addError(errors.New("PartitionBoundSpec not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printPartitionRangeDatum(node *nodes.PartitionRangeDatum) string {
-	p.addError(errors.New("PartitionRangeDatum not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printSinglePartitionSpec(node *nodes.SinglePartitionSpec) string {
-	p.addError(errors.New("SinglePartitionSpec not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printPartitionCmd(node *nodes.PartitionCmd) string {
-	p.addError(errors.New("PartitionCmd not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printRangeTblEntry(node *nodes.RangeTblEntry) string {
-	p.addError(errors.New("RangeTblEntry not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printRtepermissionInfo(node *nodes.RTEPermissionInfo) string {
-	p.addError(errors.New("RTEPermissionInfo not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printRangeTblFunction(node *nodes.RangeTblFunction) string {
-	p.addError(errors.New("RangeTblFunction not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printTableSampleClause(node *nodes.TableSampleClause) string {
-	p.addError(errors.New("TableSampleClause not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printWithCheckOption(node *nodes.WithCheckOption) string {
-	p.addError(errors.New("WithCheckOption not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printSortGroupClause(node *nodes.SortGroupClause) string {
-	p.addError(errors.New("SortGroupClause not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printGroupingSet(node *nodes.GroupingSet) string {
-	p.addError(errors.New("GroupingSet not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printWindowClause(node *nodes.WindowClause) string {
-	p.addError(errors.New("WindowClause not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printRowMarkClause(node *nodes.RowMarkClause) string {
-	p.addError(errors.New("RowMarkClause not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printInferClause(node *nodes.InferClause) string {
-	p.addError(errors.New("InferClause not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printOnConflictClause(node *nodes.OnConflictClause) string {
-	p.addError(errors.New("OnConflictClause not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printCtesearchClause(node *nodes.CTESearchClause) string {
-	p.addError(errors.New("CTESearchClause not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printCtecycleClause(node *nodes.CTECycleClause) string {
-	p.addError(errors.New("CTECycleClause not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printMergeWhenClause(node *nodes.MergeWhenClause) string {
-	p.addError(errors.New("MergeWhenClause not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printTriggerTransition(node *nodes.TriggerTransition) string {
-	p.addError(errors.New("TriggerTransition not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printJsonOutput(node *nodes.JsonOutput) string {
-	p.addError(errors.New("JsonOutput not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printJsonArgument(node *nodes.JsonArgument) string {
-	p.addError(errors.New("JsonArgument not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printJsonFuncExpr(node *nodes.JsonFuncExpr) string {
-	p.addError(errors.New("JsonFuncExpr not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printJsonTablePathSpec(node *nodes.JsonTablePathSpec) string {
-	p.addError(errors.New("JsonTablePathSpec not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printJsonTable(node *nodes.JsonTable) string {
-	p.addError(errors.New("JsonTable not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printJsonTableColumn(node *nodes.JsonTableColumn) string {
-	p.addError(errors.New("JsonTableColumn not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printJsonKeyValue(node *nodes.JsonKeyValue) string {
-	p.addError(errors.New("JsonKeyValue not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printJsonParseExpr(node *nodes.JsonParseExpr) string {
-	p.addError(errors.New("JsonParseExpr not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printJsonScalarExpr(node *nodes.JsonScalarExpr) string {
-	p.addError(errors.New("JsonScalarExpr not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printJsonSerializeExpr(node *nodes.JsonSerializeExpr) string {
-	p.addError(errors.New("JsonSerializeExpr not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printJsonObjectConstructor(node *nodes.JsonObjectConstructor) string {
-	p.addError(errors.New("JsonObjectConstructor not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printJsonArrayConstructor(node *nodes.JsonArrayConstructor) string {
-	p.addError(errors.New("JsonArrayConstructor not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printJsonArrayQueryConstructor(node *nodes.JsonArrayQueryConstructor) string {
-	p.addError(errors.New("JsonArrayQueryConstructor not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printJsonAggConstructor(node *nodes.JsonAggConstructor) string {
-	p.addError(errors.New("JsonAggConstructor not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printJsonObjectAgg(node *nodes.JsonObjectAgg) string {
-	p.addError(errors.New("JsonObjectAgg not implemented"))
-	return "NOT IMPLEMENTED"
-}
-
-func (p *printer) printJsonArrayAgg(node *nodes.JsonArrayAgg) string {
-	p.addError(errors.New("JsonArrayAgg not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printMergeStmt(node *nodes.MergeStmt) string {
-	p.addError(errors.New("MergeStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printSetOperationStmt(node *nodes.SetOperationStmt) string {
-	p.addError(errors.New("SetOperationStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printReturnStmt(node *nodes.ReturnStmt) string {
-	p.addError(errors.New("ReturnStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printPlassignStmt(node *nodes.PLAssignStmt) string {
-	p.addError(errors.New("PLAssignStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printReplicaIdentityStmt(node *nodes.ReplicaIdentityStmt) string {
-	p.addError(errors.New("ReplicaIdentityStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterCollationStmt(node *nodes.AlterCollationStmt) string {
-	p.addError(errors.New("AlterCollationStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterDomainStmt(node *nodes.AlterDomainStmt) string {
-	p.addError(errors.New("AlterDomainStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printGrantStmt(node *nodes.GrantStmt) string {
-	p.addError(errors.New("GrantStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAccessPriv(node *nodes.AccessPriv) string {
-	p.addError(errors.New("AccessPriv not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printGrantRoleStmt(node *nodes.GrantRoleStmt) string {
-	p.addError(errors.New("GrantRoleStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterDefaultPrivilegesStmt(node *nodes.AlterDefaultPrivilegesStmt) string {
-	p.addError(errors.New("AlterDefaultPrivilegesStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCopyStmt(node *nodes.CopyStmt) string {
-	p.addError(errors.New("CopyStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printVariableSetStmt(node *nodes.VariableSetStmt) string {
-	p.addError(errors.New("VariableSetStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printVariableShowStmt(node *nodes.VariableShowStmt) string {
-	p.addError(errors.New("VariableShowStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCreateTableSpaceStmt(node *nodes.CreateTableSpaceStmt) string {
-	p.addError(errors.New("CreateTableSpaceStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printDropTableSpaceStmt(node *nodes.DropTableSpaceStmt) string {
-	p.addError(errors.New("DropTableSpaceStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterTableSpaceOptionsStmt(node *nodes.AlterTableSpaceOptionsStmt) string {
-	p.addError(errors.New("AlterTableSpaceOptionsStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterTableMoveAllStmt(node *nodes.AlterTableMoveAllStmt) string {
-	p.addError(errors.New("AlterTableMoveAllStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterExtensionStmt(node *nodes.AlterExtensionStmt) string {
-	p.addError(errors.New("AlterExtensionStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterExtensionContentsStmt(node *nodes.AlterExtensionContentsStmt) string {
-	p.addError(errors.New("AlterExtensionContentsStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCreateFdwStmt(node *nodes.CreateFdwStmt) string {
-	p.addError(errors.New("CreateFdwStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterFdwStmt(node *nodes.AlterFdwStmt) string {
-	p.addError(errors.New("AlterFdwStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCreateForeignServerStmt(node *nodes.CreateForeignServerStmt) string {
-	p.addError(errors.New("CreateForeignServerStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterForeignServerStmt(node *nodes.AlterForeignServerStmt) string {
-	p.addError(errors.New("AlterForeignServerStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCreateForeignTableStmt(node *nodes.CreateForeignTableStmt) string {
-	p.addError(errors.New("CreateForeignTableStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCreateUserMappingStmt(node *nodes.CreateUserMappingStmt) string {
-	p.addError(errors.New("CreateUserMappingStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterUserMappingStmt(node *nodes.AlterUserMappingStmt) string {
-	p.addError(errors.New("AlterUserMappingStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printDropUserMappingStmt(node *nodes.DropUserMappingStmt) string {
-	p.addError(errors.New("DropUserMappingStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printImportForeignSchemaStmt(node *nodes.ImportForeignSchemaStmt) string {
-	p.addError(errors.New("ImportForeignSchemaStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCreatePolicyStmt(node *nodes.CreatePolicyStmt) string {
-	p.addError(errors.New("CreatePolicyStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterPolicyStmt(node *nodes.AlterPolicyStmt) string {
-	p.addError(errors.New("AlterPolicyStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCreateAmStmt(node *nodes.CreateAmStmt) string {
-	p.addError(errors.New("CreateAmStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCreateTrigStmt(node *nodes.CreateTrigStmt) string {
-	p.addError(errors.New("CreateTrigStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCreateEventTrigStmt(node *nodes.CreateEventTrigStmt) string {
-	p.addError(errors.New("CreateEventTrigStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterEventTrigStmt(node *nodes.AlterEventTrigStmt) string {
-	p.addError(errors.New("AlterEventTrigStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCreatePlangStmt(node *nodes.CreatePLangStmt) string {
-	p.addError(errors.New("CreatePLangStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCreateRoleStmt(node *nodes.CreateRoleStmt) string {
-	p.addError(errors.New("CreateRoleStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterRoleStmt(node *nodes.AlterRoleStmt) string {
-	p.addError(errors.New("AlterRoleStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterRoleSetStmt(node *nodes.AlterRoleSetStmt) string {
-	p.addError(errors.New("AlterRoleSetStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printDropRoleStmt(node *nodes.DropRoleStmt) string {
-	p.addError(errors.New("DropRoleStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCreateSeqStmt(node *nodes.CreateSeqStmt) string {
-	p.addError(errors.New("CreateSeqStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterSeqStmt(node *nodes.AlterSeqStmt) string {
-	p.addError(errors.New("AlterSeqStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printDefineStmt(node *nodes.DefineStmt) string {
-	p.addError(errors.New("DefineStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCreateDomainStmt(node *nodes.CreateDomainStmt) string {
-	p.addError(errors.New("CreateDomainStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCreateOpFamilyStmt(node *nodes.CreateOpFamilyStmt) string {
-	p.addError(errors.New("CreateOpFamilyStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterOpFamilyStmt(node *nodes.AlterOpFamilyStmt) string {
-	p.addError(errors.New("AlterOpFamilyStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printSecLabelStmt(node *nodes.SecLabelStmt) string {
-	p.addError(errors.New("SecLabelStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printDeclareCursorStmt(node *nodes.DeclareCursorStmt) string {
-	p.addError(errors.New("DeclareCursorStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printClosePortalStmt(node *nodes.ClosePortalStmt) string {
-	p.addError(errors.New("ClosePortalStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printFetchStmt(node *nodes.FetchStmt) string {
-	p.addError(errors.New("FetchStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printIndexStmt(node *nodes.IndexStmt) string {
-	p.addError(errors.New("IndexStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCreateStatsStmt(node *nodes.CreateStatsStmt) string {
-	p.addError(errors.New("CreateStatsStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printStatsElem(node *nodes.StatsElem) string {
-	p.addError(errors.New("StatsElem not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterStatsStmt(node *nodes.AlterStatsStmt) string {
-	p.addError(errors.New("AlterStatsStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterFunctionStmt(node *nodes.AlterFunctionStmt) string {
-	p.addError(errors.New("AlterFunctionStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printDoStmt(node *nodes.DoStmt) string {
-	p.addError(errors.New("DoStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printInlineCodeBlock(node *nodes.InlineCodeBlock) string {
-	p.addError(errors.New("InlineCodeBlock not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCallStmt(node *nodes.CallStmt) string {
-	p.addError(errors.New("CallStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCallContext(node *nodes.CallContext) string {
-	p.addError(errors.New("CallContext not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterObjectDependsStmt(node *nodes.AlterObjectDependsStmt) string {
-	p.addError(errors.New("AlterObjectDependsStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterOwnerStmt(node *nodes.AlterOwnerStmt) string {
-	p.addError(errors.New("AlterOwnerStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterOperatorStmt(node *nodes.AlterOperatorStmt) string {
-	p.addError(errors.New("AlterOperatorStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterTypeStmt(node *nodes.AlterTypeStmt) string {
-	p.addError(errors.New("AlterTypeStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printListenStmt(node *nodes.ListenStmt) string {
-	p.addError(errors.New("ListenStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printUnlistenStmt(node *nodes.UnlistenStmt) string {
-	p.addError(errors.New("UnlistenStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printTransactionStmt(node *nodes.TransactionStmt) string {
-	p.addError(errors.New("TransactionStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCreateRangeStmt(node *nodes.CreateRangeStmt) string {
-	p.addError(errors.New("CreateRangeStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printLoadStmt(node *nodes.LoadStmt) string {
-	p.addError(errors.New("LoadStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCreatedbStmt(node *nodes.CreatedbStmt) string {
-	p.addError(errors.New("CreatedbStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterDatabaseStmt(node *nodes.AlterDatabaseStmt) string {
-	p.addError(errors.New("AlterDatabaseStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterDatabaseRefreshCollStmt(node *nodes.AlterDatabaseRefreshCollStmt) string {
-	p.addError(errors.New("AlterDatabaseRefreshCollStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterDatabaseSetStmt(node *nodes.AlterDatabaseSetStmt) string {
-	p.addError(errors.New("AlterDatabaseSetStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printDropdbStmt(node *nodes.DropdbStmt) string {
-	p.addError(errors.New("DropdbStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterSystemStmt(node *nodes.AlterSystemStmt) string {
-	p.addError(errors.New("AlterSystemStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printClusterStmt(node *nodes.ClusterStmt) string {
-	p.addError(errors.New("ClusterStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printVacuumStmt(node *nodes.VacuumStmt) string {
-	p.addError(errors.New("VacuumStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printVacuumRelation(node *nodes.VacuumRelation) string {
-	p.addError(errors.New("VacuumRelation not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printRefreshMatViewStmt(node *nodes.RefreshMatViewStmt) string {
-	p.addError(errors.New("RefreshMatViewStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCheckPointStmt(node *nodes.CheckPointStmt) string {
-	p.addError(errors.New("CheckPointStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printDiscardStmt(node *nodes.DiscardStmt) string {
-	p.addError(errors.New("DiscardStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printConstraintsSetStmt(node *nodes.ConstraintsSetStmt) string {
-	p.addError(errors.New("ConstraintsSetStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printReindexStmt(node *nodes.ReindexStmt) string {
-	p.addError(errors.New("ReindexStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCreateConversionStmt(node *nodes.CreateConversionStmt) string {
-	p.addError(errors.New("CreateConversionStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printPrepareStmt(node *nodes.PrepareStmt) string {
-	p.addError(errors.New("PrepareStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printExecuteStmt(node *nodes.ExecuteStmt) string {
-	p.addError(errors.New("ExecuteStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printDeallocateStmt(node *nodes.DeallocateStmt) string {
-	p.addError(errors.New("DeallocateStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printDropOwnedStmt(node *nodes.DropOwnedStmt) string {
-	p.addError(errors.New("DropOwnedStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printReassignOwnedStmt(node *nodes.ReassignOwnedStmt) string {
-	p.addError(errors.New("ReassignOwnedStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterTsdictionaryStmt(node *nodes.AlterTSDictionaryStmt) string {
-	p.addError(errors.New("AlterTSDictionaryStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterTsconfigurationStmt(node *nodes.AlterTSConfigurationStmt) string {
-	p.addError(errors.New("AlterTSConfigurationStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printPublicationTable(node *nodes.PublicationTable) string {
-	p.addError(errors.New("PublicationTable not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printPublicationObjSpec(node *nodes.PublicationObjSpec) string {
-	p.addError(errors.New("PublicationObjSpec not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCreatePublicationStmt(node *nodes.CreatePublicationStmt) string {
-	p.addError(errors.New("CreatePublicationStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterPublicationStmt(node *nodes.AlterPublicationStmt) string {
-	p.addError(errors.New("AlterPublicationStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printCreateSubscriptionStmt(node *nodes.CreateSubscriptionStmt) string {
-	p.addError(errors.New("CreateSubscriptionStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printAlterSubscriptionStmt(node *nodes.AlterSubscriptionStmt) string {
-	p.addError(errors.New("AlterSubscriptionStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
 
 func (p *printer) printDropSubscriptionStmt(node *nodes.DropSubscriptionStmt) string {
-	p.addError(errors.New("DropSubscriptionStmt not implemented"))
+	p.addError(ErrNotImplemented)
 	return "NOT IMPLEMENTED"
 }
@@ -1997,7 +1997,7 @@ func mutateList(list []*nodes.Node, stack []*nodes.Node, v MutateFunc) {
 
 func mutate(node *nodes.Node, stack []*nodes.Node, v MutateFunc) {
 
-	if node == nil || isNilValue(*node) {
+	if node == nil || isNilValue(node) {
 		return
 	}
 
@@ -2,39 +2,37 @@ package pgtree
 
 import (
 	"fmt"
+	"strings"
 
 	pg_query "github.com/pganalyze/pg_query_go/v6"
 )
 
-// Parse uses the postgres 12 parsing engine to create a Node graph for walking and mutation.
+// Parse uses the postgres 12 parsing engine to create a Node graph for walking and mutation.  Invalid
+// SQL returns a *ParseError.
 func Parse(sql string) (*pg_query.ParseResult, error) {
-	return pg_query.Parse(sql)
+	result, err := pg_query.Parse(sql)
+	if err != nil {
+		return nil, newParseError(sql, err)
+	}
+
+	return result, nil
 }
 
+// PrintParseResult renders the statements of the parse result with minimal spacing.
 func PrintParseResult(parseResult *pg_query.ParseResult) (string, error) {
-	out := ""
-	for _, stmt := range parseResult.Stmts {
-		p, err := Print(stmt.Stmt)
-		if err != nil {
-			s, ss, err := Debug(parseResult.Stmts[0].Stmt)
-			fmt.Printf("Debug:\n%s\nGraph:\n%v\nError:%v\n", s, ss, err)
-			return "", fmt.Errorf("print:%w", err)
-		}
-		out += p
-	}
-
-	return out, nil
+	return printParseResult(parseResult, FormatOptions{})
 }
 
+// PrettyPrintParseResult renders the statements of the parse result with indented formatting.
 func PrettyPrintParseResult(parseResult *pg_query.ParseResult) (string, error) {
-	out := ""
-	for _, stmt := range parseResult.Stmts {
-		p, err := PrettyPrint(stmt.Stmt)
-		if err != nil {
-			return "", fmt.Errorf("print:%w", err)
-		}
-		out += p
+	return printParseResult(parseResult, DefaultFormat)
+}
+
+func printParseResult(parseResult *pg_query.ParseResult, opts FormatOptions) (string, error) {
+	printed, _, err := printStatements(parseResult, "", nil, opts, false, nil, nil)
+	if err != nil {
+		return "", fmt.Errorf("print:%w", err)
 	}
 
-	return out, nil
+	return strings.Join(printed, ""), nil
 }
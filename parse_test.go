@@ -75,6 +75,41 @@ func TestParseAndPretty(t *testing.T) {
 	}
 }
 
+// TestFormatGolden compares the output of Format, which reuses the spelling of the source, with the
+// files in testdata/format.
+func TestFormatGolden(t *testing.T) {
+	ff, err := filepath.Glob("testdata/format/*.sql")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, test := range ff {
+		if strings.HasSuffix(test, "_want.sql") {
+			continue
+		}
+		t.Run(test, func(t *testing.T) {
+			b, err := os.ReadFile(test)
+			if err != nil {
+				t.Fatalf("ReadFile error = %v", err)
+			}
+
+			want, err := os.ReadFile(FileWithExt(test, "_want.sql"))
+			if err != nil {
+				t.Fatalf("ReadFile error = %v", err)
+			}
+
+			got, err := pgtree.Format(string(b), pgtree.DefaultFormat)
+			if err != nil {
+				t.Fatalf("Format error = %v", err)
+			}
+
+			if w := string(want); got != w {
+				t.Errorf("Mismatch diff:\n%s", diff(got, w))
+			}
+		})
+	}
+}
+
 func diff(got, want string) string {
 	var result []string
 	gg := strings.Split(got, "\n")
@@ -98,6 +133,53 @@ func FileWithExt(path, ext string) string {
 	return strings.TrimSuffix(path, filepath.Ext(path)) + ext
 }
 
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name       string
+		sql        string
+		line       int
+		column     int
+		statement  int
+		diagnostic string
+	}{
+		{
+			"second statement", "select 1;\n;\nselect 'é', x from\n\twhere y", 4, 2, 1,
+			"q.sql:4:2: syntax error at or near \"where\"\n\twhere y\n\t^",
+		},
+		{
+			"end of input", "select 1 +", 1, 11, 0,
+			"q.sql:1:11: syntax error at end of input\nselect 1 +\n          ^",
+		},
+		{
+			"unterminated", "select 'abc", 1, 8, 0,
+			"q.sql:1:8: unterminated quoted string at or near \"'abc\"\nselect 'abc\n       ^",
+		},
+		{
+			"begin atomic", "create function f() returns int language sql\nbegin atomic select case when true then 1 end; select 2; end;\nselec 3", 3, 1, 1,
+			"q.sql:3:1: syntax error at or near \"selec\"\nselec 3\n^",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := pgtree.Parse(test.sql)
+
+			var pe *pgtree.ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("Err = %v, want a ParseError", err)
+			}
+
+			if pe.Line != test.line || pe.Column != test.column || pe.Statement != test.statement {
+				t.Errorf("got %d:%d statement %d, want %d:%d statement %d", pe.Line, pe.Column, pe.Statement, test.line, test.column, test.statement)
+			}
+
+			if got := pe.Diagnostic("q.sql"); got != test.diagnostic {
+				t.Errorf("got `%v`, want `%v`", got, test.diagnostic)
+			}
+		})
+	}
+}
+
 func testParse(sql string) (string, error) {
 	// We validate the parsing and printing by:
 	// first Parse the input SQL
@@ -0,0 +1,219 @@
+package pgtree
+
+import (
+	"strings"
+
+	nodes "github.com/pganalyze/pg_query_go/v6"
+)
+
+// precedence levels of the expression grammar, from gram.y lowest to highest binding.
+const (
+	precOr = iota + 1
+	precAnd
+	precNot
+	precIs         // IS, ISNULL, NOTNULL
+	precComparison // < > = <= >= <>
+	precLike       // BETWEEN IN LIKE ILIKE SIMILAR
+	precEscape
+	precOp // any other operator
+	precAdd
+	precMul
+	precExp
+	precAt
+	precCollate
+	precUnary
+	precSubscript
+	precTypeCast
+	precAtom
+)
+
+type associativity int
+
+const (
+	assocLeft associativity = iota
+	assocRight
+	assocNone
+)
+
+func precedenceAssociativity(prec int) associativity {
+	switch prec {
+	case precNot, precUnary:
+		return assocRight
+	case precIs, precComparison, precLike, precEscape:
+		return assocNone
+	}
+
+	return assocLeft
+}
+
+// operatorPrecedence returns the precedence of a binary operator given its (possibly qualified) name.
+func operatorPrecedence(name []string) int {
+	if len(name) != 1 {
+		// OPERATOR(schema.op) always binds as a generic operator
+		return precOp
+	}
+
+	switch name[0] {
+	case "<", ">", "=", "<=", ">=", "<>", "!=":
+		return precComparison
+	case "+", "-":
+		return precAdd
+	case "*", "/", "%":
+		return precMul
+	case "^":
+		return precExp
+	}
+
+	return precOp
+}
+
+// nodePrecedence returns the binding strength of the outermost construct printed for the node.
+func nodePrecedence(node *nodes.Node) int {
+	if node == nil {
+		return precAtom
+	}
+
+	switch n := node.Node.(type) {
+	case *nodes.Node_AExpr:
+		return aExprPrecedence(n.AExpr)
+	case *nodes.Node_BoolExpr:
+		switch n.BoolExpr.Boolop {
+		case nodes.BoolExprType_AND_EXPR:
+			return precAnd
+		case nodes.BoolExprType_OR_EXPR:
+			return precOr
+		default:
+			return precNot
+		}
+	case *nodes.Node_NullTest, *nodes.Node_BooleanTest, *nodes.Node_JsonIsPredicate:
+		return precIs
+	case *nodes.Node_XmlExpr:
+		if n.XmlExpr.Op == nodes.XmlExprOp_IS_DOCUMENT {
+			return precIs
+		}
+	case *nodes.Node_SubLink:
+		switch n.SubLink.SubLinkType {
+		case nodes.SubLinkType_ANY_SUBLINK:
+			if len(n.SubLink.OperName) == 0 {
+				return precLike
+			}
+
+			return operatorPrecedence(extractStrings(n.SubLink.OperName))
+		case nodes.SubLinkType_ALL_SUBLINK:
+			return operatorPrecedence(extractStrings(n.SubLink.OperName))
+		}
+	case *nodes.Node_FuncCall:
+		return funcCallPrecedence(n.FuncCall)
+	case *nodes.Node_TypeCast:
+		return precTypeCast
+	case *nodes.Node_CollateClause:
+		return precCollate
+	case *nodes.Node_AIndirection:
+		return precSubscript
+	case *nodes.Node_AConst:
+		if isNegativeConst(n.AConst) {
+			return precUnary
+		}
+	}
+
+	return precAtom
+}
+
+func aExprPrecedence(node *nodes.A_Expr) int {
+	switch node.Kind {
+	case nodes.A_Expr_Kind_AEXPR_OP:
+		if node.Lexpr == nil {
+			return prefixOperatorPrecedence(extractStrings(node.Name))
+		}
+
+		return operatorPrecedence(extractStrings(node.Name))
+	case nodes.A_Expr_Kind_AEXPR_OP_ANY, nodes.A_Expr_Kind_AEXPR_OP_ALL:
+		return operatorPrecedence(extractStrings(node.Name))
+	case nodes.A_Expr_Kind_AEXPR_DISTINCT, nodes.A_Expr_Kind_AEXPR_NOT_DISTINCT:
+		return precIs
+	case nodes.A_Expr_Kind_AEXPR_NULLIF:
+		return precAtom
+	}
+
+	return precLike
+}
+
+func prefixOperatorPrecedence(name []string) int {
+	if len(name) == 1 && (name[0] == "-" || name[0] == "+") {
+		return precUnary
+	}
+
+	return precOp
+}
+
+func funcCallPrecedence(node *nodes.FuncCall) int {
+	if node.Funcformat != nodes.CoercionForm_COERCE_SQL_SYNTAX {
+		return precAtom
+	}
+
+	names := extractStrings(node.Funcname)
+	if len(names) != 2 || names[0] != "pg_catalog" {
+		return precAtom
+	}
+
+	switch names[1] {
+	case "timezone":
+		return precAt
+	case "is_normalized":
+		return precIs
+	case "overlaps":
+		return precComparison
+	}
+
+	return precAtom
+}
+
+func isNegativeConst(node *nodes.A_Const) bool {
+	switch v := node.Val.(type) {
+	case *nodes.A_Const_Ival:
+		return v.Ival.Ival < 0
+	case *nodes.A_Const_Fval:
+		return strings.HasPrefix(v.Fval.Fval, "-")
+	}
+
+	return false
+}
+
+// printOperand prints the node as the left (or right) operand of an operator with precedence prec,
+// wrapping it in parentheses when the grammar would otherwise bind it differently.
+func (p *printer) printOperand(node *nodes.Node, prec int, right bool) string {
+	s := p.printNode(node)
+	child := nodePrecedence(node)
+
+	if child > prec {
+		return s
+	}
+
+	if child == prec {
+		switch precedenceAssociativity(prec) {
+		case assocLeft:
+			if !right {
+				return s
+			}
+		case assocRight:
+			if right {
+				return s
+			}
+		}
+	}
+
+	if p.Pretty && p.River && !p.wrapping() {
+		// continuation lines are indented inside the parentheses, off the river
+		s = strings.ReplaceAll(s, "\n", "\n"+p.Padding)
+	}
+
+	return p.wrapParens("(", ")", s)
+}
+
+func (p *printer) printLeftOperand(node *nodes.Node, prec int) string {
+	return p.printOperand(node, prec, false)
+}
+
+func (p *printer) printRightOperand(node *nodes.Node, prec int) string {
+	return p.printOperand(node, prec, true)
+}
@@ -8,22 +8,63 @@ import (
 
 // FormatOptions controls the formatting of the SQL output.
 type FormatOptions struct {
-	Pretty                 bool   // When enabled injects line feeds and indentation(Padding)
-	OneResultColumnPerLine bool   // Forces each result item of a select statement to a new line.  Default to true.
-	LowerKeyword           bool   // If true it forces all keywords to lowercase.  Default is to force all to uppercase.
-	UpperType              bool   // If true it forces all types to uppercase.  Default is to force all to lower.
-	SimpleLen              int    // Statements shorter than SimpleLen will disable pretty printing (default 50).
-	Padding                string // Used for indentation when Pretty printing.  Default is four spaces.
-	Unterminated           bool   // Do not add statement terminator `;`
+	Pretty                 bool          `json:"pretty" yaml:"pretty"`                                         // When enabled injects line feeds and indentation(Padding)
+	OneResultColumnPerLine bool          `json:"one_result_column_per_line" yaml:"one_result_column_per_line"` // Forces each result item of a select statement to a new line.  Default to true.
+	LowerKeyword           bool          `json:"lower_keyword" yaml:"lower_keyword"`                           // If true it forces all keywords to lowercase.  Default is to force all to uppercase.
+	UpperType              bool          `json:"upper_type" yaml:"upper_type"`                                 // If true it forces all types to uppercase.  Default is to force all to lower.
+	SimpleLen              int           `json:"simple_len" yaml:"simple_len"`                                 // Statements shorter than SimpleLen will disable pretty printing (default 50).
+	Padding                string        `json:"padding" yaml:"padding"`                                       // Used for indentation when Pretty printing.  Default is four spaces.
+	Unterminated           bool          `json:"unterminated" yaml:"unterminated"`                             // Do not add statement terminator `;`
+	PreferDollarQuote      bool          `json:"prefer_dollar_quote" yaml:"prefer_dollar_quote"`               // Render string literals that would need escaping with dollar quotes.
+	StripComments          bool          `json:"strip_comments" yaml:"strip_comments"`                         // Format drops the comments of the source instead of restoring them.
+	MaxLineWidth           int           `json:"max_line_width" yaml:"max_line_width"`                         // Break expressions that do not fit in MaxLineWidth characters, 0 disables wrapping.
+	LeadingComma           bool          `json:"leading_comma" yaml:"leading_comma"`                           // Place the comma of lists printed one item per line before the item instead of after.
+	River                  bool          `json:"river" yaml:"river"`                                           // Right align clause keywords on a shared gutter, when Pretty printing.
+	AlignColumns           bool          `json:"align_columns" yaml:"align_columns"`                           // Align column definitions and UPDATE SET assignments into columns, when Pretty printing.
+	JoinAlignFrom          bool          `json:"join_align_from" yaml:"join_align_from"`                       // Print the JOIN clauses of a FROM at the indentation of FROM instead of under it.
+	JoinOnNewLine          bool          `json:"join_on_new_line" yaml:"join_on_new_line"`                     // Put the ON condition of a JOIN on its own line, indented under the JOIN.
+	CaseOneLine            bool          `json:"case_one_line" yaml:"case_one_line"`                           // Keep CASE expressions on one line instead of one WHEN per line, unless wrapped by MaxLineWidth.
+	SubqueryIndent         int           `json:"subquery_indent" yaml:"subquery_indent"`                       // Levels the body of a multi-line sub-select is indented below its parenthesis, 0 keeps it beside.
+	TrailingBoolOp         bool          `json:"trailing_bool_op" yaml:"trailing_bool_op"`                     // Place AND/OR at the end of the line instead of the start of the next one.
+	StatementSpacing       int           `json:"statement_spacing" yaml:"statement_spacing"`                   // Blank lines printed between the statements rendered by Format.
+	CTEParenNewLine        bool          `json:"cte_paren_new_line" yaml:"cte_paren_new_line"`                 // Put the parentheses of CTE bodies, and each CTE, on their own line.
+	TableParenSpace        bool          `json:"table_paren_space" yaml:"table_paren_space"`                   // Put a space between the name of a created table and the parenthesis of its columns.
+	TypeNames              TypeNameStyle `json:"type_names" yaml:"type_names"`                                 // Spelling of the built-in types: default, verbose or concise.
+	KeepPgCatalog          bool          `json:"keep_pg_catalog" yaml:"keep_pg_catalog"`                       // Print built-in types with their internal pg_catalog qualified names.
+	Casts                  CastStyle     `json:"casts" yaml:"casts"`                                           // Syntax of type casts: colon, cast or source.
+	ShortTable             bool          `json:"short_table" yaml:"short_table"`                               // Print `SELECT * FROM x` as `TABLE x`.
+	ShortNullTest          bool          `json:"short_null_test" yaml:"short_null_test"`                       // Print `x IS NULL` and `x IS NOT NULL` as `x ISNULL` and `x NOTNULL`.
+	ShortBetween           bool          `json:"short_between" yaml:"short_between"`                           // Print `x >= a AND x <= b` as `x BETWEEN a AND b`, and its negation as NOT BETWEEN.
 }
 
-const defaultSimpleLen = 50
+// CastStyle selects the syntax of type casts.
+type CastStyle string
+
+const (
+	CastsColon    CastStyle = ""       // x::t
+	CastsFunction CastStyle = "cast"   // CAST(x AS t)
+	CastsSource   CastStyle = "source" // The syntax of the source, told apart by the locations in the parse tree.
+)
+
+// TypeNameStyle selects how the built-in types are spelled.
+type TypeNameStyle string
+
+const (
+	TypeNamesDefault TypeNameStyle = ""        // The customary names: int, boolean, varchar, timestamp with time zone.
+	TypeNamesVerbose TypeNameStyle = "verbose" // The sql standard names: integer, character varying, double precision.
+	TypeNamesConcise TypeNameStyle = "concise" // The internal names: int4, varchar, float8, timestamptz.
+)
+
+const (
+	defaultSimpleLen = 50
+	defaultPadding   = "    "
+)
 
 // DefaultFormat used by PrettyPrint.
 var DefaultFormat = FormatOptions{
 	Pretty:                 true,
 	OneResultColumnPerLine: true,
-	Padding:                "    ",
+	Padding:                defaultPadding,
 	SimpleLen:              defaultSimpleLen,
 }
 
@@ -39,22 +80,160 @@ type printer struct {
 	level       int
 	debugOutput []string
 	errs        []error
+	literals    literalSource
+	held        []string // texts replaced by placeholders until the statement is printed
+	multiline   bool     // keep the pretty layout even when the statement would fit on one line
+	statement   int      // index of the statement printed from a parse result, -1 for a single node
+	source      string   // SQL the nodes were parsed from, when known
+	bestEffort  bool     // print the nodes that fail as their source text
+	tokens      []*nodes.ScanToken
+	fallbacks   []fallback
+	passthrough []Passthrough
+	stack       []*nodes.Node
 }
 
 // PrintWithOptions renders the Node with the supplied format options.
 func PrintWithOptions(root *nodes.Node, opts FormatOptions) (string, error) {
-	p := printer{FormatOptions: opts}
+	p := printer{FormatOptions: opts, statement: -1}
+
+	return p.print(root)
+}
+
+// Format parses the SQL and renders every statement with the supplied format options.  As the
+// source is available, comments are kept and literals that the parse tree normalizes (dollar quoted
+// and unicode escaped strings, numerics) are reproduced as written.
+func Format(sql string, opts FormatOptions) (string, error) {
+	out, _, err := format(sql, opts, false)
+
+	return out, err
+}
+
+func format(sql string, opts FormatOptions, bestEffort bool) (string, []Passthrough, error) {
+	tree, err := Parse(sql)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// the passes over the source share its tokens
+	tokens := scanTokens(sql)
+
+	var commented []bool
+	if !opts.StripComments {
+		commented = commentedStatements(sql, tokens, tree.Stmts)
+	}
+
+	printed, passthrough, err := printStatements(tree, sql, tokens, opts, bestEffort, scanLiterals(sql, tokens), commented)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if opts.StripComments {
+		return strings.Join(printed, ""), passthrough, nil
+	}
+
+	return restoreComments(sql, tokens, tree.Stmts, printed, opts.Padding, passthrough), passthrough, nil
+}
+
+// printStatements prints each statement of the parse result.  In best effort mode, the nodes which
+// cannot be printed are copied from source, whose scanner tokens are tokens, and the statements
+// holding one that cannot be found in source are copied whole.
+func printStatements(tree *nodes.ParseResult, source string, tokens []*nodes.ScanToken, opts FormatOptions,
+	bestEffort bool, literals literalSource, commented []bool,
+) ([]string, []Passthrough, error) {
+	printed := make([]string, len(tree.Stmts))
+
+	var (
+		passthrough []Passthrough
+		split       []sqlToken
+	)
+
+	for i, stmt := range tree.Stmts {
+		p := printer{
+			FormatOptions: opts,
+			literals:      literals,
+			multiline:     commented != nil && commented[i],
+			statement:     i,
+			source:        source,
+			bestEffort:    bestEffort,
+			tokens:        tokens,
+		}
+
+		s, err := p.print(stmt.Stmt)
+		if err == nil {
+			passthrough = append(passthrough, p.passthrough...)
+		} else {
+			if !bestEffort {
+				return nil, nil, err
+			}
+
+			if split == nil {
+				split, _ = splitTokens(source, tokens, tree.Stmts)
+			}
+
+			start, end := statementSpan(source, split, tree.Stmts, i)
+			passthrough = append(passthrough, Passthrough{Statement: i, Start: start, End: end, Err: err})
+			s = source[start:end]
+
+			if !opts.Unterminated {
+				s = p.closeStatement(s)
+			}
+		}
+
+		printed[i] = s
+
+		if i > 0 {
+			printed[i-1] = separateStatement(printed[i-1], opts)
+		}
+	}
+
+	return printed, passthrough, nil
+}
+
+// separateStatement ends a statement followed by another one with a newline, preceded by the `;`
+// left out of unterminated statements, and the blank lines of StatementSpacing.
+func separateStatement(statement string, opts FormatOptions) string {
+	if opts.Unterminated {
+		statement = strings.TrimRight(statement, "\n") + ";"
+	}
+
+	if !strings.HasSuffix(statement, "\n") {
+		statement += "\n"
+	}
+
+	return statement + strings.Repeat("\n", opts.StatementSpacing)
+}
+
+func (p *printer) print(root *nodes.Node) (string, error) {
 	result := p.printNode(root)
 
 	if len(p.errs) > 0 {
-		return "", printError{p.errs}
+		p.locate(root, p.errs)
+
+		return "", &PrintError{p.errs}
+	}
+
+	padding := p.Padding
+	if padding == "" {
+		padding = defaultPadding
 	}
 
-	if p.Unterminated {
-		return result, nil
+	result = layout(result, p.MaxLineWidth, padding)
+
+	if !p.Unterminated {
+		result = p.closeStatement(result)
 	}
 
-	return p.closeStatement(result), nil
+	if errs := p.resolve(result); errs != nil {
+		p.locate(root, errs)
+
+		return "", &PrintError{errs}
+	}
+
+	for _, f := range p.fallbacks {
+		p.locate(root, f.errs)
+	}
+
+	return p.release(result), nil
 }
 
 // Print renders the Node with minimal spacing.
@@ -71,18 +250,28 @@ func PrettyPrint(root *nodes.Node) (string, error) {
 // the second param is an indented trace of the call graph with results.  Very useful for
 // defining new formatting rules or adding support for new Nodes.
 func Debug(root *nodes.Node) (string, []string, error) {
-	p := printer{FormatOptions: DefaultFormat, debug: true}
+	p := printer{FormatOptions: DefaultFormat, debug: true, statement: -1}
 	result := p.printNode(root)
 
 	if len(p.errs) > 0 {
-		return result, p.debugOutput, printError{p.errs}
+		p.locate(root, p.errs)
+
+		return p.release(result), p.debugOutput, &PrintError{p.errs}
 	}
 
-	return result, p.debugOutput, nil
+	return p.release(result), p.debugOutput, nil
 }
 
+// addError records the error of the node being printed.
 func (p *printer) addError(err error) {
-	p.errs = append(p.errs, err)
+	e := &NodeError{Location: -1, Statement: p.statement, Err: err}
+
+	if len(p.stack) > 0 {
+		e.node = p.stack[len(p.stack)-1]
+		e.Node = nodeName(e.node.ProtoReflect())
+	}
+
+	p.errs = append(p.errs, e)
 }
 
 func (p *printer) pad(i int) string {
@@ -112,6 +301,15 @@ func (p *printer) printNodes(list []*nodes.Node, sep string) string {
 	return b.join(sep)
 }
 
+// joinLines puts each item on its own line, separated by trailing or, with LeadingComma, leading commas.
+func (p *printer) joinLines(items []string) string {
+	if p.LeadingComma {
+		return strings.Join(items, "\n, ")
+	}
+
+	return strings.Join(items, ",\n")
+}
+
 func (p *printer) printArr(list []*nodes.Node) []string {
 	b := p.builder()
 
@@ -138,6 +336,27 @@ func (p *printer) identifier(names ...string) string {
 	return b.join(".")
 }
 
+// funcName quotes a function name.  An unqualified name is a type_function_name, while the schema of a
+// qualified name is a ColId, which allows different keywords.
+func (p *printer) funcName(names ...string) string {
+	if len(names) > 1 {
+		return qualifiedName(names, posColID)
+	}
+
+	return qualifiedName(names, posTypeFunc)
+}
+
+// paramName quotes the name of a function parameter or named argument, a type_function_name, so the
+// argument modes (OUT, INOUT, VARIADIC) and SETOF are not read as part of the parameter type.
+func (p *printer) paramName(name string) string {
+	return qualifiedName([]string{name}, posTypeFunc)
+}
+
+// typeName quotes a type name, whose first part is a type_function_name even when qualified.
+func (p *printer) typeName(names ...string) string {
+	return qualifiedName(names, posTypeFunc)
+}
+
 func (p *printer) builder() sqlBuilder {
 	return sqlBuilder{FormatOptions: p.FormatOptions}
 }
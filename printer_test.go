@@ -1,10 +1,13 @@
 package pgtree_test
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/gofoji/pgtree"
+	nodes "github.com/pganalyze/pg_query_go/v6"
 )
 
 func TestDebug(t *testing.T) {
@@ -50,6 +53,56 @@ func TestErrors(t *testing.T) {
 	}
 }
 
+func TestNodeError(t *testing.T) {
+	root, _ := pgtree.Parse("select 1; select a, b, f(x) from t")
+
+	// replace the argument of f by a node the printer does not support
+	target := root.Stmts[1].Stmt.GetSelectStmt().TargetList[2].GetResTarget()
+	target.Val.GetFuncCall().Args[0] = &nodes.Node{Node: &nodes.Node_Var{Var: &nodes.Var{Location: 25}}}
+
+	_, err := pgtree.PrintParseResult(root)
+	if !errors.Is(err, pgtree.ErrNotImplemented) {
+		t.Fatalf("Err = %v, want %v", err, pgtree.ErrNotImplemented)
+	}
+
+	if !errors.Is(err, pgtree.ErrPrinter) {
+		t.Fatalf("Err = %v, want %v", err, pgtree.ErrPrinter)
+	}
+
+	var e *pgtree.NodeError
+	if !errors.As(err, &e) {
+		t.Fatalf("Err = %v, want a NodeError", err)
+	}
+
+	want := pgtree.NodeError{
+		Node: "Var", Location: 25, Statement: 1, Path: "RawStmt > SelectStmt > targetList[2] > FuncCall > args[0]",
+		Err: pgtree.ErrNotImplemented,
+	}
+	if e.Node != want.Node || e.Location != want.Location || e.Statement != want.Statement || e.Path != want.Path {
+		t.Errorf("got %+v, want %+v", *e, want)
+	}
+
+	if e.Error() != "Var not implemented" {
+		t.Errorf("Err = %v", e)
+	}
+}
+
+func TestNodeOptionNotImplemented(t *testing.T) {
+	root, _ := pgtree.Parse("select * from a join b on true")
+
+	// semi joins are only created by the planner
+	root.Stmts[0].Stmt.GetSelectStmt().FromClause[0].GetJoinExpr().Jointype = nodes.JoinType_JOIN_SEMI
+
+	_, err := pgtree.PrintParseResult(root)
+	if !errors.Is(err, pgtree.ErrNotImplemented) || !errors.Is(err, pgtree.ErrPrinter) {
+		t.Fatalf("Err = %v, want %v", err, pgtree.ErrNotImplemented)
+	}
+
+	if want := "print:JoinExpr not implemented: unhandled JoinType: JOIN_SEMI"; err.Error() != want {
+		t.Errorf("Err = %v, want %v", err, want)
+	}
+}
+
 func TestLower(t *testing.T) {
 	tests := []struct {
 		name string
@@ -80,6 +133,626 @@ func TestLower(t *testing.T) {
 	}
 }
 
+func TestLiterals(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want string
+		opts pgtree.FormatOptions
+	}{
+		{"quote", "select 'it''s'", "SELECT 'it''s'", pgtree.DefaultFragmentFormat},
+		{"escape", `select E'a\\b\n'`, `SELECT E'a\\b\n'`, pgtree.DefaultFragmentFormat},
+		{"dollar", "select $$it's$$", "SELECT $$it's$$", pgtree.DefaultFragmentFormat},
+		{"prefer dollar", "select 'it''s', 'a$$'", "SELECT $$it's$$, 'a$$'", pgtree.FormatOptions{Unterminated: true, PreferDollarQuote: true}},
+		{"dollar tag", `select E'a$$b\\'`, `SELECT $_$a$$b\$_$`, pgtree.FormatOptions{Unterminated: true, PreferDollarQuote: true}},
+		{"unicode", `select U&'d\0061t', U&'d!0061t' uescape '!'`, `SELECT U&'d\0061t', U&'d!0061t' uescape '!'`, pgtree.DefaultFragmentFormat},
+		{"bits", "select B'0101', X'1F'", "SELECT B'0101', X'1F'", pgtree.DefaultFragmentFormat},
+		{"numeric", "select 0x1F, 1_000, 123456789012345678901234567890.000, 1e10", "SELECT 0x1F, 1_000, 123456789012345678901234567890.000, 1e10", pgtree.DefaultFragmentFormat},
+		{"typed", "select date '2020-01-01', x::boolean, interval '1' day", "SELECT date '2020-01-01', x::boolean, '1'::interval DAY", pgtree.DefaultFragmentFormat},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := pgtree.Format(test.sql, test.opts)
+			if err != nil {
+				t.Fatalf("Err = %v", err)
+			}
+			if got != test.want {
+				t.Errorf("got `%v`, want `%v`", got, test.want)
+			}
+		})
+	}
+
+	t.Run("control", func(t *testing.T) {
+		// without the source, the literal is rendered from its value
+		root, _ := pgtree.Parse(`select E'\x01b\x7f7'`)
+		want := root.Stmts[0].Stmt.GetSelectStmt().TargetList[0].GetResTarget().Val.GetAConst().GetSval().Sval
+
+		got, err := pgtree.Print(root.Stmts[0].Stmt)
+		if err != nil {
+			t.Fatalf("Err = %v", err)
+		}
+
+		if got != `SELECT E'\x01b\x7f7';` {
+			t.Errorf("got `%v`", got)
+		}
+
+		root, _ = pgtree.Parse(got)
+		if s := root.Stmts[0].Stmt.GetSelectStmt().TargetList[0].GetResTarget().Val.GetAConst().GetSval().Sval; s != want {
+			t.Errorf("reparsed %q, want %q", s, want)
+		}
+	})
+}
+
+func TestParamNames(t *testing.T) {
+	for _, name := range []string{"in", "out", "inout", "variadic", "setof"} {
+		t.Run(name, func(t *testing.T) {
+			sql := fmt.Sprintf(`create function f(%q int) returns int language sql as 'select 1'; select f(%q => 1)`, name, name)
+
+			root, err := pgtree.Parse(sql)
+			if err != nil {
+				t.Fatalf("Parse Err = %v", err)
+			}
+
+			out, err := pgtree.PrintParseResult(root)
+			if err != nil {
+				t.Fatalf("Err = %v", err)
+			}
+
+			root, err = pgtree.Parse(out)
+			if err != nil {
+				t.Fatalf("reparse `%v`: %v", out, err)
+			}
+
+			param := root.Stmts[0].Stmt.GetCreateFunctionStmt().Parameters[0].GetFunctionParameter()
+			if param.Name != name || param.Mode != nodes.FunctionParameterMode_FUNC_PARAM_DEFAULT {
+				t.Errorf("`%v` reparsed as %v parameter %q", out, param.Mode, param.Name)
+			}
+
+			arg := root.Stmts[1].Stmt.GetSelectStmt().TargetList[0].GetResTarget().Val.GetFuncCall().Args[0]
+			if arg.GetNamedArgExpr().GetName() != name {
+				t.Errorf("`%v` reparsed with argument %v", out, arg)
+			}
+		})
+	}
+}
+
+func TestStatementSeparator(t *testing.T) {
+	const pretty = "SELECT\n    alpha,\n    beta\nFROM\n    some_table\nWHERE\n    gamma = 1\n    AND delta = 2"
+
+	unterminatedPretty := pgtree.DefaultFormat
+	unterminatedPretty.Unterminated = true
+
+	tests := []struct {
+		name string
+		opts pgtree.FormatOptions
+		want string
+	}{
+		{"compact", pgtree.FormatOptions{}, "SELECT alpha, beta FROM some_table WHERE gamma = 1 AND delta = 2;\nSELECT 2;"},
+		{"unterminated", pgtree.FormatOptions{Unterminated: true}, "SELECT alpha, beta FROM some_table WHERE gamma = 1 AND delta = 2;\nSELECT 2"},
+		{"pretty", pgtree.DefaultFormat, pretty + ";\nSELECT 2;\n"},
+		{"unterminated pretty", unterminatedPretty, pretty + ";\nSELECT 2"},
+		{"spacing", pgtree.FormatOptions{StatementSpacing: 1}, "SELECT alpha, beta FROM some_table WHERE gamma = 1 AND delta = 2;\n\nSELECT 2;"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := pgtree.Format("select alpha, beta from some_table where gamma = 1 and delta = 2; select 2", test.opts)
+			if err != nil {
+				t.Fatalf("Err = %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("got `%v`, want `%v`", got, test.want)
+			}
+
+			got, _, err = pgtree.FormatBestEffort("select 1; create publication p", test.opts)
+			if err != nil {
+				t.Fatalf("Err = %v", err)
+			}
+
+			if _, err := pgtree.Parse(got); err != nil {
+				t.Errorf("best effort output `%v` does not parse: %v", got, err)
+			}
+		})
+	}
+}
+
+func TestFormatComments(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want string
+		opts pgtree.FormatOptions
+	}{
+		{"leading", "-- header\nselect a from t", "-- header\nSELECT a FROM t", pgtree.DefaultFragmentFormat},
+		{"trailing", "select a, -- first\n b from t", "SELECT a, -- first\nb FROM t", pgtree.DefaultFragmentFormat},
+		{"inline", "select /* cols */ a from t", "SELECT /* cols */ a FROM t", pgtree.DefaultFragmentFormat},
+		{"end of file", "select 1;\n-- done", "SELECT 1;\n-- done\n", pgtree.DefaultFormat},
+		{"between statements", "select 1;\n-- next\nselect 2;", "SELECT 1;\n-- next\nSELECT 2;\n", pgtree.DefaultFormat},
+		{"pretty trailing", "select a, b from t -- the table\nwhere x = 1 and y = 2", "SELECT\n    a,\n    b\nFROM\n    t -- the table\nWHERE\n    x = 1\n    AND y = 2;\n", pgtree.DefaultFormat},
+		{"strip", "-- header\nselect /* cols */ a from t", "SELECT a FROM t", pgtree.FormatOptions{Unterminated: true, StripComments: true}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := pgtree.Format(test.sql, test.opts)
+			if err != nil {
+				t.Fatalf("Err = %v", err)
+			}
+			if got != test.want {
+				t.Errorf("got `%v`, want `%v`", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMaxLineWidth(t *testing.T) {
+	pretty := pgtree.DefaultFormat
+	pretty.MaxLineWidth = 40
+
+	concise := pgtree.FormatOptions{Unterminated: true, MaxLineWidth: 40}
+
+	tests := []struct {
+		name string
+		sql  string
+		want string
+		opts pgtree.FormatOptions
+	}{
+		{"fits", "select a, b from t where x = 1 and y = 2", "SELECT a, b FROM t WHERE x = 1 AND y = 2;\n", pretty},
+		{
+			"function args", "select some_function(first_argument, second_argument) from t",
+			"SELECT\n    some_function(\n        first_argument,\n        second_argument\n    )\nFROM\n    t;\n", pretty,
+		},
+		{
+			"nested fits", "select f(g(aaaaaaaaaaaa, bbbbbbbbbbbb), h(cccccccccccc)) from t",
+			"SELECT\n    f(\n        g(aaaaaaaaaaaa, bbbbbbbbbbbb),\n        h(cccccccccccc)\n    )\nFROM\n    t;\n", pretty,
+		},
+		{
+			"in list", "select a from t where id in (1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14)",
+			"SELECT\n    a\nFROM\n    t\nWHERE\n    id IN (1, 2, 3, 4, 5, 6, 7, 8, 9,\n        10, 11, 12, 13, 14);\n", pretty,
+		},
+		{
+			"boolean chain", "select a from t where aaaaaaaaaa = 1 and (bbbbbbbbbb = 2 or cccccccccc = 3)",
+			"SELECT\n    a\nFROM\n    t\nWHERE\n    aaaaaaaaaa = 1\n    AND (\n        bbbbbbbbbb = 2 OR cccccccccc = 3\n    );\n", pretty,
+		},
+		{
+			"case", "select case when aaaaaaaaaa then 1 when bbbbbbbbbb then 2 else 3 end from t",
+			"SELECT\n    CASE\n        WHEN aaaaaaaaaa THEN 1\n        WHEN bbbbbbbbbb THEN 2\n        ELSE 3\n    END\nFROM\n    t;\n", pretty,
+		},
+		{
+			"concise", "select coalesce(first_argument, second_argument, third_argument)",
+			"SELECT COALESCE(\n    first_argument,\n    second_argument,\n    third_argument\n)", concise,
+		},
+		{"disabled", "select coalesce(first_argument, second_argument, third_argument)", "SELECT COALESCE(first_argument, second_argument, third_argument)", pgtree.DefaultFragmentFormat},
+		{
+			"dollar literal", "select $$line one   \nline two$$, some_function(first_argument) from t",
+			"SELECT\n    $$line one   \nline two$$,\n    some_function(first_argument)\nFROM\n    t;\n", pretty,
+		},
+		{"control identifier", "select \"a\x01\\b\" from t", `SELECT U&"a\0001\\b" FROM t`, concise},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := pgtree.Format(test.sql, test.opts)
+			if err != nil {
+				t.Fatalf("Err = %v", err)
+			}
+			if got != test.want {
+				t.Errorf("got `%v`, want `%v`", got, test.want)
+			}
+		})
+	}
+}
+
+func TestStyles(t *testing.T) {
+	leading := pgtree.DefaultFormat
+	leading.LeadingComma = true
+
+	river := pgtree.DefaultFormat
+	river.River = true
+
+	riverLeading := river
+	riverLeading.LeadingComma = true
+
+	tests := []struct {
+		name string
+		sql  string
+		want string
+		opts pgtree.FormatOptions
+	}{
+		{
+			"leading targets", "select aaaaaaaaaa, bbbbbbbbbb, cccccccccc from t, u",
+			"SELECT\n    aaaaaaaaaa\n    , bbbbbbbbbb\n    , cccccccccc\nFROM\n    t\n    , u;\n", leading,
+		},
+		{
+			"leading columns", "create table t (a int, b text)",
+			"CREATE TABLE t(\n    a int\n    , b text\n);\n", leading,
+		},
+		{
+			"leading values", "insert into t values (1111111111, 2), (3333333333, 4), (5555555555, 6)",
+			"INSERT INTO t VALUES\n    (1111111111, 2)\n    , (3333333333, 4)\n    , (5555555555, 6);\n", leading,
+		},
+		{
+			"river select", "select aaaaaaaaaa, bbbbbbbbbb from t left join u on t.id = u.id where x = 1 and y = 2 order by a",
+			"SELECT aaaaaaaaaa,\n       bbbbbbbbbb\n  FROM t\n  LEFT JOIN u ON t.id = u.id\n WHERE x = 1\n   AND y = 2\n ORDER BY a;\n", river,
+		},
+		{
+			"river leading", "select aaaaaaaaaa, bbbbbbbbbb, cccccccccc from t where x = 1",
+			"SELECT aaaaaaaaaa\n     , bbbbbbbbbb\n     , cccccccccc\n  FROM t\n WHERE x = 1;\n", riverLeading,
+		},
+		{
+			"river update", "update weather set temp_lo = temp_lo + 1 where city = 'San Francisco' and date = '2003-07-03' returning *",
+			"UPDATE weather\n   SET temp_lo = temp_lo + 1\n WHERE city = 'San Francisco'\n   AND date = '2003-07-03'\nRETURNING *;\n", river,
+		},
+		{
+			"river delete", "delete from a using b where a.id = b.id and b.name = 'foo'",
+			"DELETE FROM a\n USING b\n WHERE a.id = b.id\n   AND b.name = 'foo';\n", river,
+		},
+		{
+			"river union", "select aaaaaaaaaa from t where x = 1 union all select bbbbbbbbbb from u where y = 2",
+			"SELECT aaaaaaaaaa FROM t WHERE x = 1\n UNION ALL\nSELECT bbbbbbbbbb FROM u WHERE y = 2;\n", river,
+		},
+		{
+			"river operand", "select a from t where (a = $$x\n  y$$ or b = 1) and c = 2",
+			"SELECT a\n  FROM t\n WHERE (a = $$x\n  y$$\n           OR b = 1)\n   AND c = 2;\n", river,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := pgtree.Format(test.sql, test.opts)
+			if err != nil {
+				t.Fatalf("Err = %v", err)
+			}
+			if got != test.want {
+				t.Errorf("got `%v`, want `%v`", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAlignColumns(t *testing.T) {
+	opts := pgtree.DefaultFormat
+	opts.AlignColumns = true
+
+	tests := []struct {
+		name string
+		sql  string
+		want string
+	}{
+		{
+			"create table", "create table t (id int primary key, name text not null default '', created_at timestamptz, unique (name))",
+			"CREATE TABLE t(\n    id          int                     PRIMARY KEY,\n    name        text        DEFAULT ''  NOT NULL,\n" +
+				"    created_at  timestamptz,\n    UNIQUE (name)\n);\n",
+		},
+		{
+			"constraint order", "create table t (a int references u deferrable default 1, b text)",
+			"CREATE TABLE t(\n    a   int     DEFAULT 1   REFERENCES u DEFERRABLE,\n    b   text\n);\n",
+		},
+		{
+			"default before attribute", "create table t (a int references u default 1 deferrable)",
+			"CREATE TABLE t(\n    a   int REFERENCES u DEFAULT 1 DEFERRABLE\n);\n",
+		},
+		{
+			"mixed defaults",
+			"create table t (id int default 0 not null, name text default 'unnamed' unique, flag bool, at timestamptz default now() not null)",
+			"CREATE TABLE t(\n    id      int         DEFAULT 0           NOT NULL,\n" +
+				"    name    text        DEFAULT 'unnamed'   UNIQUE,\n    flag    bool,\n" +
+				"    at      timestamptz DEFAULT now()       NOT NULL\n);\n",
+		},
+		{
+			"composite type", "create type t as (x int, label text)",
+			"CREATE TYPE t AS (\n    x       int,\n    label   text\n);\n",
+		},
+		{
+			"update", "update t set a = 1, bbbbbb = 2, (c, d) = (3, 4) where x = 1",
+			"UPDATE t\nSET\n    a       = 1,\n    bbbbbb  = 2,\n    (c, d)  = (3, 4)\nWHERE\n    x = 1;\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := pgtree.Format(test.sql, opts)
+			if err != nil {
+				t.Fatalf("Err = %v", err)
+			}
+			if got != test.want {
+				t.Errorf("got `%v`, want `%v`", got, test.want)
+			}
+		})
+	}
+
+	t.Run("stable", func(t *testing.T) {
+		before, err := pgtree.Format("create table t (id int, name text)", opts)
+		if err != nil {
+			t.Fatalf("Err = %v", err)
+		}
+
+		after, err := pgtree.Format("create table t (id int, name text, label text)", opts)
+		if err != nil {
+			t.Fatalf("Err = %v", err)
+		}
+
+		want := strings.Replace(before, "    name    text\n", "    name    text,\n    label   text\n", 1)
+		if after != want {
+			t.Errorf("got `%v`, want `%v`", after, want)
+		}
+	})
+}
+
+func TestLayoutOptions(t *testing.T) {
+	with := func(set func(*pgtree.FormatOptions)) pgtree.FormatOptions {
+		opts := pgtree.DefaultFormat
+		set(&opts)
+
+		return opts
+	}
+
+	const join = "select a from t join u on t.id = u.id and t.k = u.k"
+
+	tests := []struct {
+		name string
+		sql  string
+		want string
+		opts pgtree.FormatOptions
+	}{
+		{
+			"join align from", join,
+			"SELECT\n    a\nFROM\n    t\nJOIN u ON t.id = u.id\n    AND t.k = u.k;\n",
+			with(func(o *pgtree.FormatOptions) { o.JoinAlignFrom = true }),
+		},
+		{
+			"join on new line", join,
+			"SELECT\n    a\nFROM\n    t\n    JOIN u\n        ON t.id = u.id\n        AND t.k = u.k;\n",
+			with(func(o *pgtree.FormatOptions) { o.JoinOnNewLine = true }),
+		},
+		{
+			"case one line", "select case when a then 1 when b then 2 else 3 end, x from t",
+			"SELECT\n    CASE WHEN a THEN 1 WHEN b THEN 2 ELSE 3 END,\n    x\nFROM\n    t;\n",
+			with(func(o *pgtree.FormatOptions) { o.CaseOneLine = true }),
+		},
+		{
+			"subquery indent", "select a from t where b in (select id from q where aaaaaaaaaaaa = 1)",
+			"SELECT\n    a\nFROM\n    t\nWHERE\n    b IN (\n        SELECT\n            id\n        FROM\n            q\n" +
+				"        WHERE\n            aaaaaaaaaaaa = 1\n    );\n",
+			with(func(o *pgtree.FormatOptions) { o.SubqueryIndent = 1 }),
+		},
+		{
+			"subquery indent wrapped", "select a from t where b in (select id from q where aaaaaaaaaaaa = 1)",
+			"SELECT\n    a\nFROM\n    t\nWHERE\n    b IN (\n            SELECT\n                id\n            FROM\n                q\n" +
+				"            WHERE\n                aaaaaaaaaaaa = 1\n    );\n",
+			with(func(o *pgtree.FormatOptions) { o.SubqueryIndent = 2; o.MaxLineWidth = 40 }),
+		},
+		{
+			"trailing bool op", "select a from t where aaaaaaaaaa = 1 and bbbbbbbbbb = 2 or c",
+			"SELECT\n    a\nFROM\n    t\nWHERE\n    aaaaaaaaaa = 1 AND\n    bbbbbbbbbb = 2 OR\n    c;\n",
+			with(func(o *pgtree.FormatOptions) { o.TrailingBoolOp = true }),
+		},
+		{
+			"statement spacing", "select 1; -- one\nselect 2;",
+			"SELECT 1; -- one\n\nSELECT 2;\n",
+			with(func(o *pgtree.FormatOptions) { o.StatementSpacing = 1 }),
+		},
+		{
+			"grouping set simple len", "select a from t group by rollup (aaaaaaaaaa, bbbbbbbbbb, cccccccccc, dddddddddd, eeeeeeeeee)",
+			"SELECT\n    a\nFROM\n    t\nGROUP BY ROLLUP (aaaaaaaaaa, bbbbbbbbbb, cccccccccc, dddddddddd, eeeeeeeeee);\n",
+			with(func(o *pgtree.FormatOptions) { o.SimpleLen = 0 }),
+		},
+		{
+			"cte parens", "with a as (select 1), b as (select 2) select * from a, b",
+			"WITH a AS\n(\n    SELECT 1\n),\nb AS\n(\n    SELECT 2\n)\nSELECT\n    *\nFROM\n    a,\n    b;\n",
+			with(func(o *pgtree.FormatOptions) { o.CTEParenNewLine = true }),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := pgtree.Format(test.sql, test.opts)
+			if err != nil {
+				t.Fatalf("Err = %v", err)
+			}
+			if got != test.want {
+				t.Errorf("got `%v`, want `%v`", got, test.want)
+			}
+		})
+	}
+}
+
+func TestTypeNames(t *testing.T) {
+	const sql = "select a::int, b::varchar(10), c::timestamp(3) with time zone, d::time, e::double precision, f::bit varying(5), g::pg_catalog.text, " +
+		"h::char(3), i::pg_catalog.bpchar, j::json, k::boolean, l::smallint, m::bigint, n::decimal(10, 2), o::real, p::time with time zone, q::bit(3)"
+
+	tests := []struct {
+		name string
+		want string
+		opts pgtree.FormatOptions
+	}{
+		{
+			"default",
+			"SELECT a::int, b::varchar(10), c::timestamp(3) with time zone, d::time, e::double precision, f::bit varying(5), g::pg_catalog.text, h::char(3), i::bpchar, " +
+				"j::json, k::boolean, l::smallint, m::bigint, n::numeric(10, 2), o::real, p::time with time zone, q::bit(3)",
+			pgtree.FormatOptions{Unterminated: true},
+		},
+		{
+			"verbose",
+			"SELECT a::integer, b::character varying(10), c::timestamp(3) with time zone, d::time without time zone, e::double precision, f::bit varying(5), g::pg_catalog.text, h::character(3), i::bpchar, " +
+				"j::json, k::boolean, l::smallint, m::bigint, n::numeric(10, 2), o::real, p::time with time zone, q::bit(3)",
+			pgtree.FormatOptions{Unterminated: true, TypeNames: pgtree.TypeNamesVerbose},
+		},
+		{
+			"concise",
+			"SELECT a::int4, b::varchar(10), c::timestamptz(3), d::time, e::float8, f::varbit(5), g::pg_catalog.text, h::bpchar(3), i::bpchar, " +
+				"j::json, k::bool, l::int2, m::int8, n::numeric(10, 2), o::float4, p::timetz, q::bit(3)",
+			pgtree.FormatOptions{Unterminated: true, TypeNames: pgtree.TypeNamesConcise},
+		},
+		{
+			"keep pg_catalog",
+			"SELECT a::pg_catalog.int4, b::pg_catalog.varchar(10), c::pg_catalog.timestamptz(3), d::pg_catalog.time, e::pg_catalog.float8, f::pg_catalog.varbit(5), g::pg_catalog.text, h::pg_catalog.bpchar(3), i::pg_catalog.bpchar, " +
+				"j::pg_catalog.json, k::pg_catalog.bool, l::pg_catalog.int2, m::pg_catalog.int8, n::pg_catalog.numeric(10, 2), o::pg_catalog.float4, " +
+				"p::pg_catalog.timetz, q::pg_catalog.bit(3)",
+			pgtree.FormatOptions{Unterminated: true, KeepPgCatalog: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := pgtree.Format(sql, test.opts)
+			if err != nil {
+				t.Fatalf("Err = %v", err)
+			}
+			if got != test.want {
+				t.Errorf("got `%v`, want `%v`", got, test.want)
+			}
+		})
+	}
+}
+
+func TestShorthands(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want string
+		opts pgtree.FormatOptions
+	}{
+		{"table", "create table t as table t1", "CREATE TABLE t AS TABLE t1", pgtree.FormatOptions{Unterminated: true, ShortTable: true}},
+		{"table expanded", "create table t as table t1", "CREATE TABLE t AS SELECT * FROM t1", pgtree.FormatOptions{Unterminated: true}},
+		{"table alias", "select * from t1 x", "SELECT * FROM t1 x", pgtree.FormatOptions{Unterminated: true, ShortTable: true}},
+		{"null test", "select a is null, b is not null", "SELECT a ISNULL, b NOTNULL", pgtree.FormatOptions{Unterminated: true, ShortNullTest: true}},
+		{
+			"between", "select a from t where x >= 1 and x <= 10 and y = 2 or (z < 1 or z > 2)",
+			"SELECT a FROM t WHERE x BETWEEN 1 AND 10 AND y = 2 OR z NOT BETWEEN 1 AND 2",
+			pgtree.FormatOptions{Unterminated: true, ShortBetween: true},
+		},
+		{
+			"between literal operand", "select a from t where $$x\ny$$ >= 1 and $$x\ny$$ <= 10",
+			"SELECT a FROM t WHERE $$x\ny$$ BETWEEN 1 AND 10", pgtree.FormatOptions{Unterminated: true, ShortBetween: true},
+		},
+		{
+			"between other operands", "select a from t where x >= 1 and y <= 10",
+			"SELECT a FROM t WHERE x >= 1 AND y <= 10", pgtree.FormatOptions{Unterminated: true, ShortBetween: true},
+		},
+		{"cast colon", "select cast(a as int), b::text", "SELECT a::int, b::text", pgtree.FormatOptions{Unterminated: true}},
+		{"cast function", "select cast(a as int), b::text", "SELECT CAST(a AS int), CAST(b AS text)", pgtree.FormatOptions{Unterminated: true, Casts: pgtree.CastsFunction}},
+		{
+			"cast source", "select cast(a as int), b::text, cast(c + 1 as int)::text, date '2020-01-01'",
+			"SELECT CAST(a AS int), b::text, CAST(c + 1 AS int)::text, date '2020-01-01'",
+			pgtree.FormatOptions{Unterminated: true, Casts: pgtree.CastsSource},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := pgtree.Format(test.sql, test.opts)
+			if err != nil {
+				t.Fatalf("Err = %v", err)
+			}
+			if got != test.want {
+				t.Errorf("got `%v`, want `%v`", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFormatBestEffort(t *testing.T) {
+	const sql = "select a from t;\n/* pub */ create publication p for table users, /* inner */ departments; -- done\nselect 2"
+
+	if _, err := pgtree.Format(sql, pgtree.DefaultFormat); err == nil {
+		t.Fatal("Format should fail on the unsupported statement")
+	}
+
+	got, spans, err := pgtree.FormatBestEffort(sql, pgtree.DefaultFormat)
+	if err != nil {
+		t.Fatalf("Err = %v", err)
+	}
+
+	want := "SELECT a FROM t;\n/* pub */ create publication p for table users, /* inner */ departments; -- done\nSELECT 2;\n"
+	if got != want {
+		t.Errorf("got `%v`, want `%v`", got, want)
+	}
+
+	if len(spans) != 1 || spans[0].Statement != 1 || sql[spans[0].Start:spans[0].End] != "create publication p for table users, /* inner */ departments" {
+		t.Errorf("got spans %+v", spans)
+	}
+
+	if spans[0].Err == nil || spans[0].Err.Error() != "CreatePublicationStmt not implemented" {
+		t.Errorf("Err = %v", spans[0].Err)
+	}
+
+	if _, _, err := pgtree.FormatBestEffort("select from where", pgtree.DefaultFormat); err == nil {
+		t.Error("FormatBestEffort should fail on a parse error")
+	}
+}
+
+func TestFormatBestEffortNode(t *testing.T) {
+	const sql = "select a, json_value(js, '$.a') + 1 from t;\nselect * from json_table(js, '$[*]' columns (a int path '$.a')) as jt where a > 1"
+
+	got, spans, err := pgtree.FormatBestEffort(sql, pgtree.DefaultFormat)
+	if err != nil {
+		t.Fatalf("Err = %v", err)
+	}
+
+	want := "SELECT a, json_value(js, '$.a') + 1 FROM t;\n" +
+		"SELECT * FROM json_table(js, '$[*]' columns (a int path '$.a')) as jt WHERE a > 1;\n"
+	if got != want {
+		t.Errorf("got `%v`, want `%v`", got, want)
+	}
+
+	wantSpans := []string{"json_value(js, '$.a')", "json_table(js, '$[*]' columns (a int path '$.a')) as jt"}
+	if len(spans) != len(wantSpans) {
+		t.Fatalf("got spans %+v", spans)
+	}
+
+	for i, span := range spans {
+		if span.Statement != i || sql[span.Start:span.End] != wantSpans[i] {
+			t.Errorf("got span %+v", span)
+		}
+	}
+
+	if spans[0].Err == nil || spans[0].Err.Error() != "JsonFuncExpr not implemented" {
+		t.Errorf("Err = %v", spans[0].Err)
+	}
+}
+
+func TestPrintBestEffort(t *testing.T) {
+	const sql = "select json_query(js, '$.a' returning text) as q from t; create publication p"
+
+	tree, err := pgtree.Parse(sql)
+	if err != nil {
+		t.Fatalf("Parse Err = %v", err)
+	}
+
+	got, spans, err := pgtree.PrintBestEffort(tree.Stmts[0].Stmt, sql, pgtree.FormatOptions{})
+	if err != nil {
+		t.Fatalf("Err = %v", err)
+	}
+
+	if want := "SELECT json_query(js, '$.a' returning text) AS q FROM t;"; got != want {
+		t.Errorf("got `%v`, want `%v`", got, want)
+	}
+
+	if len(spans) != 1 || spans[0].Statement != -1 || sql[spans[0].Start:spans[0].End] != "json_query(js, '$.a' returning text)" {
+		t.Errorf("got spans %+v", spans)
+	}
+
+	if _, _, err := pgtree.PrintBestEffort(tree.Stmts[1].Stmt, sql, pgtree.FormatOptions{}); err == nil {
+		t.Error("PrintBestEffort should fail on a node without location")
+	}
+
+	got, spans, err = pgtree.PrintParseResultBestEffort(tree, sql, pgtree.FormatOptions{})
+	if err != nil {
+		t.Fatalf("Err = %v", err)
+	}
+
+	if want := "SELECT json_query(js, '$.a' returning text) AS q FROM t;\ncreate publication p;"; got != want {
+		t.Errorf("got `%v`, want `%v`", got, want)
+	}
+
+	if len(spans) != 2 || spans[1].Statement != 1 || sql[spans[1].Start:spans[1].End] != "create publication p" {
+		t.Errorf("got spans %+v", spans)
+	}
+}
+
 func ExamplePrint() {
 	sql := "select * from foo left join bar on foo.id = bar.id;"
 
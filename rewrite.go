@@ -0,0 +1,266 @@
+package pgtree
+
+import (
+	nodes "github.com/pganalyze/pg_query_go/v6"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// PathStep is the position of a node in its parent.
+type PathStep struct {
+	Parent *nodes.Node // Closest Node holding the field.
+	Field  string      // Field of the parent, e.g. targetList, nested messages are joined by a dot, e.g. intoClause.rel.
+	Index  int         // Index of the node in the list, -1 when the field is not a list.
+}
+
+// RewriteFunc is called by Rewrite for each node with a Cursor positioned on it.
+type RewriteFunc func(c *Cursor) bool
+
+// Cursor describes the node visited by Rewrite and edits it in its parent.
+type Cursor struct {
+	node    *nodes.Node
+	path    []PathStep
+	owner   protoreflect.Message // message holding the field, nil for the root
+	field   protoreflect.FieldDescriptor
+	list    protoreflect.List
+	index   int
+	skip    int  // nodes inserted after the current node
+	deleted bool // the current node was deleted, c.index+1 is its former slot in the list
+	abort   bool
+}
+
+// Node returns the current node.
+func (c *Cursor) Node() *nodes.Node {
+	return c.node
+}
+
+// Path returns the steps from the root to the current node, the root has an empty path.
+func (c *Cursor) Path() []PathStep {
+	return append([]PathStep(nil), c.path...)
+}
+
+// Parent returns the closest Node holding the current node, nil for the root.
+func (c *Cursor) Parent() *nodes.Node {
+	return c.step().Parent
+}
+
+// Field returns the field of the parent holding the current node, empty for the root.
+func (c *Cursor) Field() string {
+	return c.step().Field
+}
+
+// Index returns the index of the current node in its list, -1 when it is not in a list.
+func (c *Cursor) Index() int {
+	return c.step().Index
+}
+
+func (c *Cursor) step() PathStep {
+	if len(c.path) == 0 {
+		return PathStep{Index: -1}
+	}
+
+	return c.path[len(c.path)-1]
+}
+
+// Replace replaces the current node, nil deletes it.  A deleted node is replaced at its former slot.
+// The children of the new node are walked after the pre callback returns.
+func (c *Cursor) Replace(node *nodes.Node) {
+	switch {
+	case node == nil && c.owner != nil:
+		c.Delete()
+	case c.list != nil && c.deleted:
+		c.insert(c.index+1, node)
+		c.index++
+		c.deleted = false
+		c.path[len(c.path)-1].Index = c.index
+	case c.list != nil:
+		c.list.Set(c.index, protoreflect.ValueOfMessage(node.ProtoReflect()))
+	case c.owner != nil:
+		c.owner.Set(c.field, protoreflect.ValueOfMessage(node.ProtoReflect()))
+	}
+
+	c.node = node
+}
+
+// Delete removes the current node from its list, or clears its field.  The root cannot be deleted, nor
+// a node deleted twice.
+func (c *Cursor) Delete() {
+	if c.deleted {
+		panic("pgtree: Delete of a deleted node")
+	}
+
+	if c.list == nil {
+		if c.owner == nil {
+			panic("pgtree: Delete of the root")
+		}
+
+		c.owner.Clear(c.field)
+		c.node = nil
+
+		return
+	}
+
+	for i := c.index + 1; i < c.list.Len(); i++ {
+		c.list.Set(i-1, c.list.Get(i))
+	}
+
+	c.list.Truncate(c.list.Len() - 1)
+	c.index--
+	c.deleted = true
+	c.node = nil
+	c.path[len(c.path)-1].Index = c.index
+}
+
+// InsertBefore inserts a node before the current node in its list, or at the former slot of a deleted
+// node, it is not walked by Rewrite.
+func (c *Cursor) InsertBefore(node *nodes.Node) {
+	at := c.index
+	if c.deleted {
+		at++
+	}
+
+	c.insert(at, node)
+	c.index++
+	c.path[len(c.path)-1].Index = c.index
+}
+
+// InsertAfter inserts a node after the current node in its list, or at the former slot of a deleted
+// node, it is not walked by Rewrite.
+func (c *Cursor) InsertAfter(node *nodes.Node) {
+	c.insert(c.index+1, node)
+	c.skip++
+}
+
+func (c *Cursor) insert(at int, node *nodes.Node) {
+	if c.list == nil {
+		panic("pgtree: insert of a node not in a list")
+	}
+
+	c.list.Append(protoreflect.ValueOfMessage(node.ProtoReflect()))
+
+	for i := c.list.Len() - 1; i > at; i-- {
+		c.list.Set(i, c.list.Get(i-1))
+	}
+
+	c.list.Set(at, protoreflect.ValueOfMessage(node.ProtoReflect()))
+}
+
+// Rewrite walks the tree from root in depth-first order, calling pre before the children of a
+// node and post after them, either can be nil.  The callbacks edit the tree through the Cursor.
+// When pre returns false the children of the node and post are skipped, when post returns false
+// the walk stops.  Rewrite returns the root, which is changed when the callbacks replace it.
+//
+//	root = pgtree.Rewrite(root, nil, func(c *pgtree.Cursor) bool {
+//		if c.Field() == "targetList" && c.Index() > 0 {
+//			c.Delete()
+//		}
+//
+//		return true
+//	})
+func Rewrite(root *nodes.Node, pre, post RewriteFunc) *nodes.Node {
+	c := Cursor{node: root, index: -1}
+	rewrite(&c, pre, post)
+
+	return c.node
+}
+
+func rewrite(c *Cursor, pre, post RewriteFunc) {
+	if c.node == nil {
+		return
+	}
+
+	if pre != nil && !pre(c) {
+		return
+	}
+
+	if c.node == nil {
+		return
+	}
+
+	if m := nodeMessage(c.node); m != nil {
+		rewriteFields(c, c.node, m, "", pre, post)
+	}
+
+	if c.abort || c.node == nil {
+		return
+	}
+
+	if post != nil && !post(c) {
+		c.abort = true
+	}
+}
+
+// rewriteFields walks the nodes held by the fields of m, the messages other than Node are walked
+// through with their field added to the prefix.
+func rewriteFields(c *Cursor, parent *nodes.Node, m protoreflect.Message, prefix string, pre, post RewriteFunc) {
+	fields := m.Descriptor().Fields()
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		if field.Message() == nil || !m.Has(field) {
+			continue
+		}
+
+		name := prefix + field.JSONName()
+		isNode := field.Message().FullName() == nodeDescriptor.FullName()
+
+		switch {
+		case field.IsList() && isNode:
+			rewriteList(c, parent, m, field, name, pre, post)
+		case field.IsList():
+			// only lists of Node are walked
+		case isNode:
+			node, _ := m.Get(field).Message().Interface().(*nodes.Node)
+			child := Cursor{
+				node:  node,
+				path:  append(c.path, PathStep{Parent: parent, Field: name, Index: -1}),
+				owner: m,
+				field: field,
+				index: -1,
+			}
+			rewrite(&child, pre, post)
+			c.abort = child.abort
+		default:
+			rewriteFields(c, parent, m.Mutable(field).Message(), name+".", pre, post)
+		}
+
+		if c.abort {
+			return
+		}
+	}
+}
+
+func rewriteList(c *Cursor, parent *nodes.Node, m protoreflect.Message, field protoreflect.FieldDescriptor,
+	name string, pre, post RewriteFunc,
+) {
+	child := Cursor{owner: m, field: field, list: m.Mutable(field).List()}
+
+	for child.index = 0; child.index < child.list.Len(); child.index++ {
+		child.node, _ = child.list.Get(child.index).Message().Interface().(*nodes.Node)
+		child.path = append(c.path, PathStep{Parent: parent, Field: name, Index: child.index})
+
+		rewrite(&child, pre, post)
+
+		child.index += child.skip
+		child.skip = 0
+		child.deleted = false
+
+		if child.abort {
+			c.abort = true
+
+			return
+		}
+	}
+}
+
+var nodeDescriptor = (&nodes.Node{}).ProtoReflect().Descriptor()
+
+// nodeMessage returns the message held by a Node.
+func nodeMessage(node *nodes.Node) protoreflect.Message {
+	m := node.ProtoReflect()
+
+	field := m.WhichOneof(nodeDescriptor.Oneofs().ByName("node"))
+	if field == nil {
+		return nil
+	}
+
+	return m.Get(field).Message()
+}
@@ -0,0 +1 @@
+select greatest(a,b), least(1,2,3), nullif(a,b), a is true, a is not true, a is false, a is not false, a is unknown, a is not unknown, row(a,b) is null, (a,b) is not null, (a+b) is null, not a is true, (a = b) is true, (a, b) < (c, d), (a,b) = (c,d), greatest(a,b) is null from t where coalesce(greatest(x, y), 0) > 1 and flag is not false;
@@ -0,0 +1,23 @@
+SELECT
+    GREATEST(a, b),
+    LEAST(1, 2, 3),
+    NULLIF(a, b),
+    a IS TRUE,
+    a IS NOT TRUE,
+    a IS FALSE,
+    a IS NOT FALSE,
+    a IS UNKNOWN,
+    a IS NOT UNKNOWN,
+    ROW(a, b) IS NULL,
+    (a, b) IS NOT NULL,
+    a + b IS NULL,
+    NOT a IS TRUE,
+    a = b IS TRUE,
+    (a, b) < (c, d),
+    (a, b) = (c, d),
+    GREATEST(a, b) IS NULL
+FROM
+    t
+WHERE
+    COALESCE(GREATEST(x, y), 0) > 1
+    AND flag IS NOT FALSE;
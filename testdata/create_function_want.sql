@@ -1,10 +1,8 @@
 CREATE FUNCTION foo(bar text, cow int=1) RETURNS bool AS
-'SELECT TRUE' LANGUAGE sql;
+' SELECT TRUE ' LANGUAGE sql;
 CREATE OR REPLACE FUNCTION foo(bar int) RETURNS text AS
-'SELECT "baz"' LANGUAGE plpgsql;
+' SELECT "baz" ' LANGUAGE plpgsql;
 CREATE OR REPLACE FUNCTION foo(bar int) RETURNS text AS
-$$
-SELECT 'baz' FROM foo
-$$ LANGUAGE plpgsql;
+$$ SELECT 'baz' FROM foo $$ LANGUAGE plpgsql;
 CREATE FUNCTION f() RETURNS void AS
 '' LANGUAGE sql;
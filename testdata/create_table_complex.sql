@@ -69,3 +69,4 @@ WITH (
     toast.vacuum_truncate=false,
     user_catalog_table=false)
 TABLESPACE diskvol1;
+create table deferred (a int references u deferrable initially deferred default 1, b int not null default 0, foreign key (b) references u (id) deferrable);
@@ -2,7 +2,7 @@ CREATE UNLOGGED TABLE IF NOT EXISTS complex_table(
     did int PRIMARY KEY,
     did2 int CHECK (did2 > 100),
     no_null_int int CONSTRAINT no_null NOT NULL,
-    no_null_varchar varchar(40) NOT NULL COLLATE "es_ES",
+    no_null_varchar varchar(40) COLLATE "es_ES" NOT NULL,
     some_unique int8 UNIQUE,
     some_varchar varchar(40),
     some_num numeric,
@@ -63,3 +63,8 @@ WITH (
     USER_CATALOG_TABLE=FALSE
 )
 TABLESPACE diskvol1;
+CREATE TABLE deferred(
+    a int REFERENCES u DEFERRABLE INITIALLY DEFERRED DEFAULT 1,
+    b int NOT NULL DEFAULT 0,
+    FOREIGN KEY (b) REFERENCES u (id) DEFERRABLE
+);
@@ -0,0 +1,10 @@
+create table a1 (like base including all excluding indexes);
+create table a2 (like base);
+create table a3 (like base including defaults including constraints, extra int);
+create table a4 (like base including all);
+create table a5 (id int) inherits (p1, s.p2) using heap with (fillfactor=70) tablespace ts;
+create table a6 (a text storage external compression lz4 collate "C" not null, b text storage default) without oids;
+create table a7 of mytype;
+create table a8 of mytype (id with options primary key);
+create temp table a9 (id int) on commit drop;
+create table a10 (a text compression default, b text storage plain compression pglz);
@@ -0,0 +1,38 @@
+CREATE TABLE a1(
+    LIKE base INCLUDING ALL EXCLUDING INDEXES
+);
+CREATE TABLE a2(
+    LIKE base
+);
+CREATE TABLE a3(
+    LIKE base INCLUDING CONSTRAINTS INCLUDING DEFAULTS,
+    extra int
+);
+CREATE TABLE a4(
+    LIKE base INCLUDING ALL
+);
+CREATE TABLE a5(
+    id int
+)
+INHERITS (p1, s.p2)
+USING heap
+WITH (
+    FILLFACTOR=70
+)
+TABLESPACE ts;
+CREATE TABLE a6(
+    a text STORAGE EXTERNAL COMPRESSION lz4 COLLATE "C" NOT NULL,
+    b text STORAGE DEFAULT
+);
+CREATE TABLE a7 OF mytype;
+CREATE TABLE a8 OF mytype(
+    id PRIMARY KEY
+);
+CREATE TEMP TABLE a9(
+    id int
+)
+ON COMMIT DROP;
+CREATE TABLE a10(
+    a text COMPRESSION DEFAULT,
+    b text STORAGE PLAIN COMPRESSION pglz
+);
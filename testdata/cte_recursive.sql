@@ -0,0 +1,4 @@
+with recursive search_tree(id, link, data) as (select t.id, t.link, t.data from tree t union all select t.id, t.link, t.data from tree t, search_tree st where t.id = st.link) search depth first by id set ordercol select * from search_tree order by ordercol;
+with recursive g(id, link) as (select id, link from graph union all select g.id, g.link from graph g join g2 on g.id = g2.link) search breadth first by id, link set ord cycle id set is_cycle using path select * from g;
+with recursive g(id) as (select 1 union all select id + 1 from g) cycle id set done to 'Y' default 'N' using p select * from g;
+with w as materialized (select 1), v ("A", b) as not materialized (select 1, 2) select * from w, v;
@@ -0,0 +1,54 @@
+WITH RECURSIVE search_tree(id, link, data) AS (
+    SELECT
+        t.id,
+        t.link,
+        t.data
+    FROM
+        tree t
+    UNION ALL
+    SELECT
+        t.id,
+        t.link,
+        t.data
+    FROM
+        tree t,
+        search_tree st
+    WHERE
+        t.id = st.link
+) SEARCH DEPTH FIRST BY id SET ordercol
+SELECT
+    *
+FROM
+    search_tree
+ORDER BY ordercol;
+WITH RECURSIVE g(id, link) AS (
+    SELECT id, link FROM graph
+    UNION ALL
+    SELECT
+        g.id,
+        g.link
+    FROM
+        graph g
+        JOIN g2 ON g.id = g2.link
+) SEARCH BREADTH FIRST BY id, link SET ord CYCLE id SET is_cycle USING path
+SELECT
+    *
+FROM
+    g;
+WITH RECURSIVE g(id) AS (
+    SELECT 1 UNION ALL SELECT id + 1 FROM g
+) CYCLE id SET done TO 'Y' DEFAULT 'N' USING p
+SELECT
+    *
+FROM
+    g;
+WITH w AS MATERIALIZED (
+    SELECT 1
+), v("A", b) AS NOT MATERIALIZED (
+    SELECT 1, 2
+)
+SELECT
+    *
+FROM
+    w,
+    v;
@@ -0,0 +1,7 @@
+select $$x
+  y$$ as v from t where a = 1 and b = 2;
+create function f(a int) returns int as $$
+begin
+  return a + 1;
+end
+$$ language plpgsql;
@@ -0,0 +1,14 @@
+SELECT
+    $$x
+  y$$ AS v
+FROM
+    t
+WHERE
+    a = 1
+    AND b = 2;
+CREATE FUNCTION f(a int) RETURNS int AS
+$$
+begin
+  return a + 1;
+end
+$$ LANGUAGE plpgsql;
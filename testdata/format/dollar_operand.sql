@@ -0,0 +1,4 @@
+select a from t where (a = $$x
+  y$$ or b = 1) and c = 2;
+select a from t join (u join v on u.id = $$x
+  y$$) on true;
@@ -0,0 +1,17 @@
+SELECT
+    a
+FROM
+    t
+WHERE
+    (a = $$x
+  y$$
+    OR b = 1)
+    AND c = 2;
+SELECT
+    a
+FROM
+    t
+    JOIN (u
+        JOIN v ON u.id = $$x
+  y$$
+    ) ON true;
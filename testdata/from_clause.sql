@@ -0,0 +1,12 @@
+select * from t tablesample system (10) repeatable (42), u as x tablesample bernoulli (5.5);
+select * from t, lateral (select * from u where u.id = t.id) s, lateral f(t.x) g;
+select * from rows from (f(1), g(2) as (a int, b text)) with ordinality as r(x, y, n);
+select * from unnest(array[1,2]) with ordinality as u(v, n);
+select * from json_to_record('{}') as x(a int, "B" text);
+select * from a join b using (id) as j left join c on c.id = j.id;
+select * from (a join b on a.id = b.id) as ab;
+select * from a cross join lateral f(a.x) as y;
+select * from generate_series(1, 10) g;
+select * from t as "Order"("Col", b);
+select * from a join (b join c on b.id = c.id) on a.id = b.id;
+select * from a left join (b join c using (id)) as bc (x, y) on true;
@@ -0,0 +1,56 @@
+SELECT
+    *
+FROM
+    t TABLESAMPLE system(10) REPEATABLE (42),
+    u x TABLESAMPLE bernoulli(5.5);
+SELECT
+    *
+FROM
+    t,
+    LATERAL (SELECT * FROM u WHERE u.id = t.id) s,
+    LATERAL f(t.x) AS g;
+SELECT
+    *
+FROM
+    ROWS FROM (f(1), g(2) AS (a int, b text)) WITH ORDINALITY AS r(x, y, n);
+SELECT
+    *
+FROM
+    unnest(ARRAY[1, 2]) WITH ORDINALITY AS u(v, n);
+SELECT
+    *
+FROM
+    json_to_record('{}') AS x (a int, "B" text);
+SELECT
+    *
+FROM
+    a
+    JOIN b USING (id) AS j
+    LEFT JOIN c ON c.id = j.id;
+SELECT
+    *
+FROM
+    (a
+        JOIN b ON a.id = b.id
+    ) ab;
+SELECT
+    *
+FROM
+    a
+    CROSS JOIN LATERAL f(a.x) AS y;
+SELECT * FROM generate_series(1, 10) AS g;
+SELECT * FROM t "Order"("Col", b);
+SELECT
+    *
+FROM
+    a
+    JOIN (b
+        JOIN c ON b.id = c.id
+    ) ON a.id = b.id;
+SELECT
+    *
+FROM
+    a
+    LEFT JOIN (b
+        JOIN c USING (id)
+    ) bc(x, y) ON true;
@@ -0,0 +1,38 @@
+SELECT
+    brand,
+    size,
+    GROUPING(brand, size),
+    sum(sales)
+FROM
+    items_sold
+GROUP BY ROLLUP (brand, size);
+SELECT
+    brand,
+    size,
+    sum(sales)
+FROM
+    items_sold
+GROUP BY CUBE ((brand, size), color);
+SELECT
+    region,
+    product,
+    channel,
+    GROUPING(region, product, channel) AS grp,
+    sum(amount) AS total
+FROM
+    sales
+GROUP BY GROUPING SETS (
+    (region, product, channel),
+    (region, channel),
+    product,
+    region,
+    ()
+);
+SELECT
+    a,
+    b,
+    c,
+    count(*)
+FROM
+    t
+GROUP BY DISTINCT a, ROLLUP (b), CUBE (c), ();
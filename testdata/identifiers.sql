@@ -0,0 +1,7 @@
+select "my col", "1st", "a$b", a$b, "say ""hi""", "Upper", "left", "between", t."from", "select".x, "join" as "order", 1 as from from "user" join "left" as "right" using ("natural") where "table".x = 1;
+select "float"(1), "left"(s, 2), "values"(1), s."values"(1), "left".f(), "select".g(1), "char"::text, x::"Custom Type" from t;
+create table "Mixed Table" ("id col" int, "check" int, "between" text, "verbose" bool);
+create table t2 (a int constraint "Positive" check (a > 0), b int, constraint "B Unique" unique (b)) tablespace "Fast Disk";
+alter table t2 rename column "b" to "B col";
+create function f("out" int, "inout" int, "variadic" int, "setof" int, "in" int) returns int language sql as 'select 1';
+select f("out" => 1, "setof" => 2);
@@ -0,0 +1,45 @@
+SELECT
+    "my col",
+    "1st",
+    a$b,
+    a$b,
+    "say ""hi""",
+    "Upper",
+    "left",
+    between,
+    t.from,
+    "select".x,
+    "join" AS order,
+    1 AS from
+FROM
+    "user"
+    JOIN "left" "right" USING ("natural")
+WHERE
+    "table".x = 1;
+SELECT
+    "float"(1),
+    left(s, 2),
+    "values"(1),
+    s.values(1),
+    "left".f(),
+    "select".g(1),
+    char::text,
+    x::"Custom Type"
+FROM
+    t;
+CREATE TABLE "Mixed Table"(
+    "id col" int,
+    "check" int,
+    between text,
+    "verbose" bool
+);
+CREATE TABLE t2(
+    a int CONSTRAINT "Positive" CHECK (a > 0),
+    b int,
+    CONSTRAINT "B Unique" UNIQUE (b)
+)
+TABLESPACE "Fast Disk";
+ALTER TABLE t2 RENAME b TO "B col";
+CREATE FUNCTION f("out" int, "inout" int, "variadic" int, "setof" int, "in" int) RETURNS int LANGUAGE sql AS
+'select 1';
+SELECT f("out" => 1, "setof" => 2);
@@ -0,0 +1,4 @@
+select arr[1], arr[2:3], arr[:2], arr[2:], (c).field, (r).*, (f(x)).a[1], name collate "C", (a || b) collate "de_DE", a.b[1].c, ($1).x, $1[1], (arr[1])[2], (row(1,2)).f1, (a::int[])[1], x collate pg_catalog."default" from t;
+create table t (a text collate "C" not null, b int);
+update t set a[1] = 2, b.c = 3;
+insert into t (a[1], b.c) values (1,2);
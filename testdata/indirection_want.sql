@@ -0,0 +1,27 @@
+SELECT
+    arr[1],
+    arr[2:3],
+    arr[:2],
+    arr[2:],
+    (c).field,
+    (r).*,
+    (f(x)).a[1],
+    name COLLATE "C",
+    (a || b) COLLATE "de_DE",
+    a.b[1].c,
+    ($1).x,
+    $1[1],
+    (arr[1])[2],
+    (ROW(1, 2)).f1,
+    (a::int[])[1],
+    x COLLATE pg_catalog.default
+FROM
+    t;
+CREATE TABLE t(
+    a text COLLATE "C" NOT NULL,
+    b int
+);
+UPDATE t
+SET
+    a[1] = 2, b.c = 3;
+INSERT INTO t(a[1], b.c) VALUES (1, 2);
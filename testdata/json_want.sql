@@ -0,0 +1,27 @@
+SELECT
+    JSON_OBJECT('id' VALUE u.id, 'name' VALUE u.name ABSENT ON NULL WITH UNIQUE KEYS RETURNING jsonb)
+FROM
+    users u;
+SELECT
+    JSON_ARRAY(1, NULL, 'a' NULL ON NULL),
+    JSON_ARRAY(),
+    JSON_ARRAY(SELECT id FROM users RETURNING jsonb);
+SELECT
+    JSON_OBJECTAGG(k VALUE v),
+    JSON_ARRAYAGG(x ORDER BY y DESC RETURNING jsonb) FILTER (WHERE x > 1) OVER (PARTITION BY z)
+FROM
+    t;
+SELECT
+    *
+FROM
+    docs
+WHERE
+    body IS JSON OBJECT WITH UNIQUE KEYS
+    AND NOT meta IS JSON ARRAY
+    AND raw IS JSON SCALAR;
+SELECT
+    JSON('{"a": 1}' WITH UNIQUE KEYS),
+    JSON_SCALAR(now()),
+    JSON_SERIALIZE(doc FORMAT JSON ENCODING UTF8 RETURNING bytea)
+FROM
+    docs;
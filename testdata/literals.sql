@@ -0,0 +1 @@
+select 'it''s', E'a\\b', E'line\nbreak\ttab', true, 't'::bool, x::boolean, 'f'::boolean, date '2020-01-01', '2020-01-01'::date, cast('1' as int), timestamp with time zone '2020-01-01 00:00', interval '1 day', interval '1' day, B'0101', X'1F', 123456789012345678901234567890.000, 0x1F, 1_000, 1e10, U&'d\0061t\+000061', U&'d!0061t' uescape '!', $$it's$$, $fn$a$$b$fn$, -1.50, 'plain';
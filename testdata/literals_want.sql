@@ -0,0 +1,26 @@
+SELECT
+    'it''s',
+    E'a\\b',
+    E'line\nbreak\ttab',
+    true,
+    't'::bool,
+    x::boolean,
+    false,
+    date '2020-01-01',
+    '2020-01-01'::date,
+    '1'::int,
+    timestamp with time zone '2020-01-01 00:00',
+    interval '1 day',
+    '1'::interval DAY,
+    B'0101',
+    X'1F',
+    123456789012345678901234567890.000,
+    31,
+    1000,
+    1e10,
+    'data',
+    'dat',
+    'it''s',
+    'a$$b',
+    -1.50,
+    'plain';
@@ -0,0 +1,70 @@
+SELECT
+    (a + b) * c,
+    a + b * c,
+    a - (b - c),
+    a - b - c,
+    2 ^ (3 ^ 2),
+    -(a + b),
+    -a,
+    @ -x,
+    |/25,
+    a OPERATOR(pg_catalog.+) b,
+    (a + b)::int,
+    (-1)::text
+FROM
+    t;
+SELECT
+    *
+FROM
+    t
+WHERE
+    a IS DISTINCT FROM b
+    AND c IS NOT DISTINCT FROM d = e
+    AND (a = b) = c
+    AND NOT (a
+    OR b)
+    AND NOT a = b;
+SELECT
+    NULLIF(a, b),
+    a = ANY(arr),
+    a <> ALL(arr),
+    a NOT IN (1, 2),
+    x IN (SELECT id FROM u),
+    x > ALL (SELECT id FROM u),
+    x <= ANY (SELECT id FROM u),
+    ARRAY(SELECT id FROM u)
+FROM
+    t;
+SELECT
+    *
+FROM
+    t
+WHERE
+    a BETWEEN 1 AND 10
+    AND b NOT BETWEEN c + 1 AND d
+    AND e BETWEEN SYMMETRIC 10 AND 1
+    AND f NOT BETWEEN SYMMETRIC (g IS NULL) AND h;
+SELECT
+    *
+FROM
+    t
+WHERE
+    name LIKE 'a%'
+    AND name NOT LIKE 'b!%' ESCAPE '!'
+    AND name ILIKE 'c%'
+    AND name NOT ILIKE 'd%'
+    AND name SIMILAR TO 'e%'
+    AND name NOT SIMILAR TO 'f#%' ESCAPE '#';
+SELECT
+    (a
+    OR b)
+    AND c,
+    a
+    OR b
+    AND c,
+    a = b IS NULL,
+    (a IS NULL) IS NOT NULL,
+    (ts AT TIME ZONE 'utc')::date,
+    a || b IS NOT NULL
+FROM
+    t;
@@ -0,0 +1,13 @@
+SELECT dept, string_agg(DISTINCT name, ', ' ORDER BY name DESC), array_agg(salary ORDER BY hired NULLS LAST),
+    count(*) FILTER (WHERE active), percentile_cont(0.5) WITHIN GROUP (ORDER BY salary),
+    sum(salary) FILTER (WHERE salary > 0) OVER (PARTITION BY dept)
+FROM employees
+GROUP BY dept;
+SELECT concat_ws(',', VARIADIC ARRAY['a', 'b']), format('%s %s', 'x', VARIADIC ARRAY['y']);
+SELECT EXTRACT(year FROM hired), substring(name FROM 2 FOR 3), substring(name FROM 2), trim(both 'x' FROM name),
+    trim(leading FROM name), trim(trailing '-' FROM name), overlay(name PLACING 'ab' FROM 2 FOR 3), position('a' IN name),
+    collation for (name), normalize(name, nfkc), hired AT TIME ZONE 'utc'
+FROM employees
+WHERE name IS NFC NORMALIZED;
+
+select distinct a, b from t;
@@ -0,0 +1,30 @@
+SELECT
+    dept,
+    string_agg(DISTINCT name, ', ' ORDER BY name DESC),
+    array_agg(salary ORDER BY hired NULLS LAST),
+    count(*) FILTER (WHERE active),
+    percentile_cont(0.5) WITHIN GROUP (ORDER BY salary),
+    sum(salary) FILTER (WHERE salary > 0) OVER (PARTITION BY dept)
+FROM
+    employees
+GROUP BY dept;
+SELECT
+    concat_ws(',', VARIADIC ARRAY['a', 'b']),
+    format('%s %s', 'x', VARIADIC ARRAY['y']);
+SELECT
+    EXTRACT(YEAR FROM hired),
+    SUBSTRING(name FROM 2 FOR 3),
+    SUBSTRING(name FROM 2),
+    TRIM(BOTH 'x' FROM name),
+    TRIM(LEADING FROM name),
+    TRIM(TRAILING '-' FROM name),
+    OVERLAY(name PLACING 'ab' FROM 2 FOR 3),
+    POSITION('a' IN name),
+    COLLATION FOR (name),
+    NORMALIZE(name, NFKC),
+    hired AT TIME ZONE 'utc'
+FROM
+    employees
+WHERE
+    name IS NFC NORMALIZED;
+SELECT DISTINCT a, b FROM t;
@@ -24,15 +24,15 @@ FROM
         (3, 'three')) t(num, letter) USING (num)
 WHERE
     fooey > ALL (SELECT * FROM foobar)
-    OR (EXISTS(SELECT * FROM foo2)
+    OR EXISTS(SELECT * FROM foo2)
     AND b.x IS NOT NULL
-    AND b.y IS NULL)
-    OR ((f.sum > 100
+    AND b.y IS NULL
+    OR (f.sum > 100
     OR f.sum = 20
     OR f.sum < 1)
-    AND b.bool)
-    OR ($1 <> f."XXX"
-    AND test.foo = ANY($2::bigserial[]))
+    AND b.bool
+    OR $1 <> f."XXX"
+    AND test.foo = ANY($2::bigserial[])
 GROUP BY b.moo
 HAVING b.foo > 100
 ORDER BY b.order_field DESC NULLS LAST, fieldx ASC NULLS FIRST
@@ -2,4 +2,11 @@ SELECT depname, empno, salary, rank() OVER (PARTITION BY depname ORDER BY salary
 SELECT salary, sum(salary) OVER () FROM empsalary;
 SELECT sum(salary) OVER w, avg(salary) OVER w
 FROM empsalary
-    WINDOW w AS (PARTITION BY depname ORDER BY salary DESC);
\ No newline at end of file
+    WINDOW w AS (PARTITION BY depname ORDER BY salary DESC);
+select sum(x) over (order by t rows between 2 preceding and current row) from s;
+select sum(x) over (order by t range between interval '1 day' preceding and unbounded following exclude current row) from s;
+select sum(x) over (order by t groups between 1 preceding and 1 following exclude group) from s;
+select sum(x) over (order by t rows unbounded preceding exclude ties) from s;
+select sum(x) over (order by t range current row exclude no others) from s;
+select sum(x) over (w order by t), avg(x) over (w rows 3 preceding), count(*) over w from s window w as (partition by k), w2 as (w order by y), w3 as () order by 1;
+select rank() over (partition by a, b order by c desc nulls last range between unbounded preceding and current row) from s;
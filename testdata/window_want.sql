@@ -16,3 +16,35 @@ SELECT
 FROM
     empsalary
 WINDOW w AS (PARTITION BY depname ORDER BY salary DESC);
+SELECT
+    sum(x) OVER (ORDER BY t ROWS BETWEEN 2 PRECEDING AND CURRENT ROW)
+FROM
+    s;
+SELECT
+    sum(x) OVER (ORDER BY t RANGE BETWEEN interval '1 day' PRECEDING AND UNBOUNDED FOLLOWING EXCLUDE CURRENT ROW)
+FROM
+    s;
+SELECT
+    sum(x) OVER (ORDER BY t GROUPS BETWEEN 1 PRECEDING AND 1 FOLLOWING EXCLUDE GROUP)
+FROM
+    s;
+SELECT
+    sum(x) OVER (ORDER BY t ROWS UNBOUNDED PRECEDING EXCLUDE TIES)
+FROM
+    s;
+SELECT
+    sum(x) OVER (ORDER BY t RANGE CURRENT ROW)
+FROM
+    s;
+SELECT
+    sum(x) OVER (w ORDER BY t),
+    avg(x) OVER (w ROWS 3 PRECEDING),
+    count(*) OVER w
+FROM
+    s
+WINDOW w AS (PARTITION BY k), w2 AS (w ORDER BY y), w3 AS ()
+ORDER BY 1;
+SELECT
+    rank() OVER (PARTITION BY a, b ORDER BY c DESC NULLS LAST RANGE BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)
+FROM
+    s;
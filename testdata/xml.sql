@@ -0,0 +1,7 @@
+SELECT xmlelement(name item, xmlattributes(p.id AS "itemId", p.sku), xmlforest(p.name, p.price AS cost), 'note') FROM products p;
+SELECT xmlpi(name php, 'echo "hello";'), xmlconcat(a, b), xmlroot(doc, version '1.0', standalone yes), xmlroot(doc, version no value, standalone no value) FROM docs;
+SELECT xmlparse(document '<a/>' preserve whitespace), xmlparse(content body), xmlserialize(document body AS text indent) FROM docs WHERE body IS DOCUMENT AND raw IS NOT DOCUMENT;
+SELECT x.*
+FROM feeds f,
+    LATERAL XMLTABLE(XMLNAMESPACES('http://example.com/ns' AS ns, DEFAULT 'http://example.com/d'), '/rows/row' PASSING BY REF f.body
+        COLUMNS id int PATH '@id', ord FOR ORDINALITY, name text PATH 'ns:name' DEFAULT 'unknown' NOT NULL) AS x;
@@ -0,0 +1,32 @@
+SELECT
+    XMLELEMENT(NAME item, XMLATTRIBUTES(p.id AS "itemId", p.sku), XMLFOREST(p.name, p.price AS cost), 'note')
+FROM
+    products p;
+SELECT
+    XMLPI(NAME php, 'echo "hello";'),
+    XMLCONCAT(a, b),
+    XMLROOT(doc, VERSION '1.0', STANDALONE YES),
+    XMLROOT(doc, VERSION NO VALUE, STANDALONE NO VALUE)
+FROM
+    docs;
+SELECT
+    XMLPARSE(DOCUMENT '<a/>' PRESERVE WHITESPACE),
+    XMLPARSE(CONTENT body),
+    XMLSERIALIZE(DOCUMENT body AS text INDENT)
+FROM
+    docs
+WHERE
+    body IS DOCUMENT
+    AND NOT raw IS DOCUMENT;
+SELECT
+    x.*
+FROM
+    feeds f,
+    LATERAL XMLTABLE(
+        XMLNAMESPACES('http://example.com/ns' AS ns, DEFAULT 'http://example.com/d'),
+        '/rows/row' PASSING f.body
+        COLUMNS
+            id int PATH '@id',
+            ord FOR ORDINALITY,
+            name text PATH 'ns:name' DEFAULT 'unknown' NOT NULL
+    ) AS x;